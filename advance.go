@@ -0,0 +1,88 @@
+package arbiterid
+
+import (
+	"fmt"
+	"time"
+)
+
+// GenerateAfter creates a new ID of the given type that is strictly greater than minimum,
+// such as an ID received from another system that the new ID must sort after.
+//
+// If minimum has the same type and a timestamp past the node's current time, the node's
+// internal time is advanced to that millisecond (or the next, if its sequences cannot
+// exceed minimum) rather than waiting for the wall clock, so the result may be
+// future-dated. The advance is bounded by one second (or the WithMaxFutureDrift limit,
+// if set); beyond that ErrClockTooFarAhead is returned.
+// Because the type occupies the most significant bits, a type lower than minimum's type
+// can never exceed it and yields ErrMinimumUnreachable.
+func (n *Node) GenerateAfter(idType IDType, minimum ID) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+	minType := int64(minimum) >> n.layout.typeShift()
+	if minimum >= 0 && int64(n.stampType(idType)) < minType {
+		return 0, fmt.Errorf("%w: type %d sorts below minimum %d of type %d",
+			ErrMinimumUnreachable, idType, minimum, minType)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	wall := n.currentMillis()
+	if err := n.checkRestoredClock(wall); err != nil {
+		return 0, err
+	}
+	now := wall
+	if now < n.time {
+		now = n.time
+	}
+	if minimum >= 0 && int64(n.stampType(idType)) == minType {
+		if minMillis := int64(minimum) >> n.layout.timeShift() & n.layout.timeMax(); now < minMillis {
+			now = minMillis - minMillis%n.granularity
+		}
+	}
+
+	// Walk forward through sequences and milliseconds until the candidate exceeds minimum
+	var seq int64
+	for {
+		if err := n.checkAdvance(now, wall); err != nil {
+			return 0, err
+		}
+		var ok bool
+		if seq, ok = n.seqAllocator.Next(now); !ok {
+			n.sequenceRollovers++
+			now += n.granularity
+			continue
+		}
+		if n.pack(idType, now, seq) > minimum {
+			break
+		}
+	}
+	if err := n.setSeq(seq); err != nil {
+		return 0, err
+	}
+	return n.generateInternal(idType, now)
+}
+
+// GenerateChild creates a new ID of the given type for a child of parent in hierarchical
+// data such as a thread or tree, guaranteed to sort after the parent. It is GenerateAfter
+// with parent as the minimum, so successive children are ordered among themselves too,
+// with the same future-dating bounds and ErrMinimumUnreachable for a type lower than the
+// parent's. A parent that is not positive yields ErrInvalidID.
+func (n *Node) GenerateChild(idType IDType, parent ID) (ID, error) {
+	if parent <= 0 {
+		return 0, fmt.Errorf("%w: parent %d is not positive", ErrInvalidID, parent)
+	}
+	return n.GenerateAfter(idType, parent)
+}
+
+// checkAdvance rejects deliberately advancing the internal time to millis when that is
+// further ahead of the wall clock than maxFutureAdvance allows.
+func (n *Node) checkAdvance(millis, wall int64) error {
+	limit := n.maxFutureAdvance()
+	if drift := time.Duration(millis-wall) * time.Millisecond; drift > limit {
+		return fmt.Errorf("%w: advancing to %dms would be %s ahead of wall clock %dms (max %s)",
+			ErrClockTooFarAhead, millis, drift, wall, limit)
+	}
+	return nil
+}
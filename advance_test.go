@@ -0,0 +1,113 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateAfter(t *testing.T) {
+	fixed := time.Now().UTC().Truncate(time.Millisecond)
+	nowFunc := WithNowFunc(func() time.Time { return fixed })
+
+	t.Run("Minimum ahead of clock", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc)
+		other := newTestNode(t, testNodeID1, WithQuietMode(true))
+		minimum, err := other.GenerateWithTimestamp(testType1, fixed.Add(200*time.Millisecond))
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+
+		id, err := node.GenerateAfter(testType1, minimum)
+		if err != nil {
+			t.Fatalf("GenerateAfter failed: %v", err)
+		}
+		if id <= minimum {
+			t.Errorf("GenerateAfter returned %d, want > %d", id, minimum)
+		}
+
+		// Regular generation continues after the advanced ID
+		next, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if next <= id {
+			t.Errorf("Generate after GenerateAfter returned %d, want > %d", next, id)
+		}
+	})
+
+	t.Run("Minimum already behind", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc)
+		minimum := node.GenerateSimple(testType1)
+		id, err := node.GenerateAfter(testType1, minimum)
+		if err != nil {
+			t.Fatalf("GenerateAfter failed: %v", err)
+		}
+		if id <= minimum || id.Time() != minimum.Time() {
+			t.Errorf("GenerateAfter returned %d, want > %d in the same millisecond", id, minimum)
+		}
+	})
+
+	t.Run("Higher type always exceeds", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc)
+		minimum := ID(int64(testType1)<<TypeShift | TimestampMask)
+		id, err := node.GenerateAfter(IDType(2), minimum)
+		if err != nil {
+			t.Fatalf("GenerateAfter failed: %v", err)
+		}
+		if id <= minimum {
+			t.Errorf("GenerateAfter returned %d, want > %d", id, minimum)
+		}
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc)
+		minimum := ID(int64(IDType(5)) << TypeShift)
+		if _, err := node.GenerateAfter(testType1, minimum); !errors.Is(err, ErrMinimumUnreachable) {
+			t.Errorf("Expected ErrMinimumUnreachable for lower type, got %v", err)
+		}
+
+		far, err := node.GenerateWithTimestamp(testType0, fixed.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		if _, err := newTestNode(t, testNodeID1, WithQuietMode(true), nowFunc).GenerateAfter(testType0, far); !errors.Is(err, ErrClockTooFarAhead) {
+			t.Errorf("Expected ErrClockTooFarAhead for a minimum an hour ahead, got %v", err)
+		}
+	})
+}
+
+func TestGenerateChild(t *testing.T) {
+	fixed := time.Now().UTC().Truncate(time.Millisecond)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return fixed }))
+
+	// A parent from another node, slightly ahead of this node's clock
+	parent, err := newTestNode(t, testNodeID1, WithQuietMode(true)).GenerateWithTimestamp(testType1, fixed.Add(5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+
+	var prev ID
+	for i := 0; i < 5; i++ {
+		child, err := node.GenerateChild(testType1, parent)
+		if err != nil {
+			t.Fatalf("GenerateChild %d failed: %v", i, err)
+		}
+		if child <= parent {
+			t.Errorf("Child %d (%d) does not sort after parent %d", i, child, parent)
+		}
+		if child <= prev {
+			t.Errorf("Child %d (%d) does not sort after previous child %d", i, child, prev)
+		}
+		prev = child
+	}
+
+	for _, bad := range []ID{0, -1} {
+		if _, err := node.GenerateChild(testType1, bad); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Expected ErrInvalidID for parent %d, got %v", bad, err)
+		}
+	}
+	if _, err := node.GenerateChild(testType0, parent); !errors.Is(err, ErrMinimumUnreachable) {
+		t.Errorf("Expected ErrMinimumUnreachable for a lower type, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package arbiterid
 
 import (
+	"context"
+	"crypto/cipher"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
@@ -8,8 +10,11 @@ import (
 	"log"
 	"math"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
 )
 
 // Constants for bit allocation in the ID
@@ -48,12 +53,26 @@ const (
 	maxRolloverWaitAttempts   = 2000
 	rolloverWaitCheckInterval = time.Microsecond * 50
 	maxEarlyAttempts          = 10 // Maximum attempts to check for fresh time
+
+	// defaultMaxFutureAdvance bounds how far methods that deliberately advance the internal
+	// time (GenerateBatch, GenerateAfter) may push it ahead of the wall clock
+	defaultMaxFutureAdvance = time.Second
 )
 
-// Encoding maps for Base32 and Base58
+// timeISOMillisLayout is RFC 3339 with fixed millisecond precision
+const timeISOMillisLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// shortIDBase58Marker is prefixed by ID.ShortID to name the encoding that follows
+const shortIDBase58Marker = '5'
+
+// formattedGroupSize is the number of characters between dashes in ID.Formatted
+const formattedGroupSize = 4
+
+// Encoding maps for Base32, Base58 and Base62
 const (
 	encodeBase32Map = "ybndrfg8ejkmcpqxot1uwisza345h769"
 	encodeBase58Map = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+	encodeBase62Map = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 )
 
 // Error definitions
@@ -62,15 +81,31 @@ var (
 	ErrInvalIDType           = errors.New("arbiterid: ID type must be between 0 and 1023") // Updated for 10 bits
 	ErrInvalidBase58         = errors.New("arbiterid: invalid base58 string")
 	ErrInvalidBase32         = errors.New("arbiterid: invalid base32 string")
+	ErrInvalidBase62         = errors.New("arbiterid: invalid base62 string")
 	ErrMonotonicityViolation = errors.New("arbiterid: generated ID is not strictly greater than the last ID")
 	ErrClockNotAdvancing     = errors.New("arbiterid: system clock appears to be stuck or moving backward excessively")
 	ErrBase64InvalidLength   = errors.New("arbiterid: invalid base64 ID length, expected 8 decoded bytes")
+	ErrInvalidShortID        = errors.New("arbiterid: invalid short ID")
+	ErrBinaryInvalidLength   = errors.New("arbiterid: invalid binary ID length, expected 8 bytes")
+	ErrInvalidBatchCount     = errors.New("arbiterid: batch count must be positive")
+	ErrBatchTooLarge         = errors.New("arbiterid: batch would push timestamps too far ahead of the wall clock")
+	ErrInvalidSequence       = errors.New("arbiterid: sequence allocator returned an out-of-range sequence")
+	ErrSelfCheckFailed       = errors.New("arbiterid: node self-check failed")
+	ErrClockTooFarAhead      = errors.New("arbiterid: generation time is too far ahead of the wall clock")
+	ErrMinimumUnreachable    = errors.New("arbiterid: cannot generate an ID greater than the requested minimum")
+	ErrGenerationPanicked    = errors.New("arbiterid: ID generation panicked")
+	ErrTimestampReused       = errors.New("arbiterid: timestamp is older than one already used")
+	ErrSequenceExhausted     = errors.New("arbiterid: sequence exhausted")
+	ErrTimestampOverflow     = errors.New("arbiterid: timestamp has overflowed")
+	ErrInvalidMinimumID      = errors.New("arbiterid: invalid minimum ID")
+	ErrInvalidComponent      = errors.New("arbiterid: ID component out of range")
 )
 
 // Decoding maps, initialized in init()
 var (
 	decodeBase32Map [256]byte
 	decodeBase58Map [256]byte
+	decodeBase62Map [256]byte
 )
 
 func init() {
@@ -80,12 +115,18 @@ func init() {
 	for i := range decodeBase58Map {
 		decodeBase58Map[i] = 0xFF
 	}
+	for i := range decodeBase62Map {
+		decodeBase62Map[i] = 0xFF
+	}
 	for i := 0; i < len(encodeBase32Map); i++ {
 		decodeBase32Map[encodeBase32Map[i]] = byte(i)
 	}
 	for i := 0; i < len(encodeBase58Map); i++ {
 		decodeBase58Map[encodeBase58Map[i]] = byte(i)
 	}
+	for i := 0; i < len(encodeBase62Map); i++ {
+		decodeBase62Map[encodeBase62Map[i]] = byte(i)
+	}
 }
 
 // ID represents an arbiterid unique identifier
@@ -98,12 +139,41 @@ type IDType uint16
 type Node struct {
 	mu                       sync.Mutex
 	epoch                    time.Time
+	layout                   Layout           // Section widths; DefaultLayout unless WithBitLayout is set
+	now                      func() time.Time // Time source used by Generate
+	manualClock              *ManualClock     // Set by WithManualClock so AdvanceClock can drive it
 	lastID                   ID
 	node                     int64
 	time                     int64
 	seq                      int64
+	seqAllocator             SequenceAllocator
 	clockWarningCount        int64
+	sequenceRollovers        int64             // Times a millisecond's sequences ran out and generation moved on
+	monotonicityViolations   int64             // Times generation failed with ErrMonotonicityViolation
+	maxFutureDrift           time.Duration     // Zero disables the check
+	granularity              int64             // Timestamps are rounded down to a multiple of this many milliseconds
+	generated                atomic.Int64      // Successful generations, readable without the mutex
+	atomicLastID             atomic.Int64      // Mirror of lastID, readable without the mutex
+	history                  *recentHistory    // Nil unless WithRecentHistory is set
+	idempotency              *idempotencyCache // Nil unless WithIdempotencyCache is set
+	middleware               []func(next GenerateFunc) GenerateFunc
+	generate                 GenerateFunc             // Middleware chain around generateCore; nil without middleware
+	maxReplayTime            int64                    // Latest GenerateWithTimestamp millisecond seen by the replay guard
+	timestampReplayGuard     bool                     // Rejects GenerateWithTimestamp timestamps older than maxReplayTime
+	typeVersionBits          uint8                    // Top type bits holding a version; zero disables versioning
+	registeredTypes          TypeRegistry             // Nil unless WithRegisteredTypesOnly is set
+	hostHash                 func(host []byte) uint32 // Set by WithHostHash; only read by NewNodeFromHost
+	cursorKey                []byte                   // HMAC key for Cursor; nil unless WithCursorKey is set
+	region                   uint8                    // Region code stamped into the top type bits when hasRegion is set
+	hasRegion                bool                     // Set by WithRegionCode
+	restoredTime             int64                    // Last time of the NodeState restored by NewNodeFromState until the clock reaches it
+	randomSequenceStart      bool                     // Set by WithRandomSequenceStart
+	obfuscationKey           []byte                   // AES key set by WithObfuscationKey
+	obfuscator               cipher.Block             // Cipher for obfuscationKey; nil without a key
 	strictMonotonicityChecks bool
+	selfCheck                bool // Verifies the layout round-trips in NewNode
+	typeAgnosticMonotonicity bool // Ignores the type bits when checking monotonicity
+	lockFreeLastID           bool // LastID reads atomicLastID instead of taking the mutex
 	quietMode                bool // Suppresses most log output for testing
 }
 
@@ -118,6 +188,86 @@ func WithStrictMonotonicityCheck(enable bool) NodeOption {
 	}
 }
 
+// WithTypeAgnosticMonotonicity makes the strict monotonicity check compare only the
+// (timestamp, node, sequence) portion of IDs, ignoring the type bits.
+// Default is false. Because the type occupies the most significant bits, interleaving
+// types at the same timestamp would otherwise trip the check whenever a lower type
+// follows a higher one. IDs remain unique either way, since the sequence still advances.
+func WithTypeAgnosticMonotonicity(enable bool) NodeOption {
+	return func(n *Node) {
+		n.typeAgnosticMonotonicity = enable
+	}
+}
+
+// WithNowFunc sets the function Generate uses to read the current time.
+// Default is time.Now. This is the lightweight way to inject a fixed or controllable
+// time source in tests. A nil function is ignored.
+func WithNowFunc(now func() time.Time) NodeOption {
+	return func(n *Node) {
+		if now != nil {
+			n.now = now
+			n.manualClock = nil
+		}
+	}
+}
+
+// WithMaxFutureDrift makes Generate return ErrClockTooFarAhead instead of issuing an ID
+// whose timestamp is more than d ahead of the wall clock. The node's internal time can run
+// ahead after the wall clock moves backwards, after GenerateWithTimestamp with a future
+// timestamp, or after GenerateBatch spreads a batch over upcoming milliseconds; this option
+// keeps such IDs from becoming noticeably future-dated. It also tightens the GenerateBatch
+// bound to d. Default is 0, which disables the check.
+func WithMaxFutureDrift(d time.Duration) NodeOption {
+	return func(n *Node) {
+		n.maxFutureDrift = d
+	}
+}
+
+// WithClockGranularity rounds generation timestamps down to a multiple of d (counted from
+// the epoch and truncated to whole milliseconds), e.g. 10ms. Leaving the low timestamp bits
+// constant improves run-length and columnar compression of stored IDs. The trade-off is
+// fewer distinct timestamps: all IDs within a granule share one sequence space, so
+// SeqMax+1 IDs per granule (rather than per millisecond) is the ceiling, and sequence
+// exhaustion waits for the next granule. Default is 1ms, i.e. no coarsening.
+func WithClockGranularity(d time.Duration) NodeOption {
+	return func(n *Node) {
+		n.granularity = max(d.Milliseconds(), 1)
+	}
+}
+
+// WithTimestampReplayGuard makes GenerateWithTimestamp return ErrTimestampReused for a
+// timestamp older than the latest one it has been called with, catching replays that go
+// out of order. Repeating the latest timestamp is still allowed and continues its sequence.
+// Default is false.
+func WithTimestampReplayGuard(enable bool) NodeOption {
+	return func(n *Node) {
+		n.timestampReplayGuard = enable
+	}
+}
+
+// WithMinimumID seeds the node's last ID with floor, so that with strict monotonicity
+// checks enabled (the default) every generated ID must exceed it. Use it when migrating
+// from another ID scheme, with the largest legacy ID as the floor. Generation fails with
+// ErrMonotonicityViolation until the clock passes the floor's timestamp, and always for
+// types below the floor's type unless WithTypeAgnosticMonotonicity is set.
+// NewNode returns ErrInvalidMinimumID for a negative floor.
+func WithMinimumID(floor ID) NodeOption {
+	return func(n *Node) {
+		n.lastID = floor
+	}
+}
+
+// WithAtomicLastID makes LastID read an atomic copy of the last ID instead of taking the
+// generation mutex, so frequent readers such as a health endpoint polling it do not
+// contend with Generate. The copy is updated right after each ID is recorded, so a
+// concurrent LastID may briefly return the previous ID while a generation is in progress.
+// Default is false.
+func WithAtomicLastID(enable bool) NodeOption {
+	return func(n *Node) {
+		n.lockFreeLastID = enable
+	}
+}
+
 // WithQuietMode enables or disables quiet mode to suppress most log output.
 // Default is false. Set to true to reduce logging during testing or high-volume environments.
 func WithQuietMode(enable bool) NodeOption {
@@ -128,15 +278,14 @@ func WithQuietMode(enable bool) NodeOption {
 
 // NewNode creates a new Node for generating IDs with the given options
 func NewNode(nodeID int, options ...NodeOption) (*Node, error) {
-	if int64(nodeID) < 0 || int64(nodeID) > NodeMax {
-		return nil, fmt.Errorf("%w: got %d, max %d", ErrInvalidNodeID, nodeID, NodeMax)
-	}
-
 	epochTime := time.Unix(Epoch/1000, (Epoch%1000)*1000000).UTC()
 
 	n := &Node{
 		node:                     int64(nodeID),
 		epoch:                    epochTime,
+		layout:                   DefaultLayout,
+		now:                      time.Now,
+		granularity:              1,
 		time:                     0,
 		seq:                      0,
 		lastID:                   0,
@@ -147,6 +296,41 @@ func NewNode(nodeID int, options ...NodeOption) (*Node, error) {
 	for _, option := range options {
 		option(n)
 	}
+	if err := n.layout.Validate(); err != nil {
+		return nil, err
+	}
+	if int64(nodeID) < 0 || int64(nodeID) > n.layout.nodeMax() {
+		return nil, fmt.Errorf("%w: got %d, max %d", ErrInvalidNodeID, nodeID, n.layout.nodeMax())
+	}
+	if n.seqAllocator == nil {
+		a := newIncrementAllocator(n.layout.SeqBits)
+		a.randomStart = n.randomSequenceStart
+		n.seqAllocator = a
+	}
+	if err := n.validateEpoch(); err != nil {
+		return nil, err
+	}
+	if n.lastID < 0 {
+		return nil, fmt.Errorf("%w: %d is outside the 63-bit ID range", ErrInvalidMinimumID, n.lastID)
+	}
+	n.atomicLastID.Store(int64(n.lastID))
+	if n.typeVersionBits >= n.layout.TypeBits {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidTypeVersioning, n.typeVersionBits)
+	}
+	if err := n.validateRegion(); err != nil {
+		return nil, err
+	}
+	if err := n.initObfuscation(); err != nil {
+		return nil, err
+	}
+	if len(n.middleware) > 0 {
+		n.generate = n.buildGenerateChain()
+	}
+	if n.selfCheck {
+		if err := n.runSelfCheck(); err != nil {
+			return nil, err
+		}
+	}
 	if !n.quietMode {
 		log.Printf("ArbiterID Node initialized: ID=%d, StrictMonotonicityChecks=%t, QuietMode=%t", n.node, n.strictMonotonicityChecks, n.quietMode)
 	}
@@ -155,15 +339,52 @@ func NewNode(nodeID int, options ...NodeOption) (*Node, error) {
 
 // Generate creates a new unique ID with the given type and current timestamp.
 // This method includes clock rollover detection for production safety.
+// Middleware added with WithGenerateMiddleware runs around each call.
 func (n *Node) Generate(idType IDType) (ID, error) {
-	if uint16(idType) > TypeMax {
-		return 0, fmt.Errorf("%w: got %d, max %d", ErrInvalIDType, idType, TypeMax)
+	if n.generate != nil {
+		return n.generate(idType)
+	}
+	return n.generateCore(idType)
+}
+
+// generateCore is Generate without middleware.
+func (n *Node) generateCore(idType IDType) (ID, error) {
+	return n.generateCoreContext(context.Background(), idType)
+}
+
+// generateCoreContext is GenerateContext without middleware.
+func (n *Node) generateCoreContext(ctx context.Context, idType IDType) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
 	}
 
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	now := time.Now().UTC().Sub(n.epoch).Milliseconds()
+	return n.generateLocked(ctx, idType)
+}
+
+// GenerateContext is Generate, but if the sequence is exhausted it abandons the wait for
+// the clock to reach the next millisecond as soon as ctx is done, returning ctx.Err()
+// instead of sleeping out the full rollover budget. Middleware runs as for Generate. With
+// a context that is never cancelled it behaves exactly like Generate.
+func (n *Node) GenerateContext(ctx context.Context, idType IDType) (ID, error) {
+	if len(n.middleware) == 0 {
+		return n.generateCoreContext(ctx, idType)
+	}
+	return n.chainAround(func(idType IDType) (ID, error) {
+		return n.generateCoreContext(ctx, idType)
+	})(idType)
+}
+
+// generateLocked is Generate for a valid type with the node's mutex already held. ctx
+// only bounds the wait for the next millisecond when the sequence is exhausted.
+func (n *Node) generateLocked(ctx context.Context, idType IDType) (ID, error) {
+	wall := n.currentMillis()
+	if err := n.checkRestoredClock(wall); err != nil {
+		return 0, err
+	}
+	now := wall
 
 	// Clock rollover detection - only for Generate() using real time
 	if now < n.time {
@@ -182,46 +403,122 @@ func (n *Node) Generate(idType IDType) (ID, error) {
 		now = n.time
 	}
 
-	// Handle sequence rollover with real time - can wait and advance
-	if now == n.time {
-		n.seq = (n.seq + 1) & SeqMax
-		if n.seq == 0 {
-			// Sequence exhausted, need to wait for next millisecond
-			originalTime := n.time
-			attempts := 0
-			for now <= originalTime {
-				attempts++
-				if attempts > maxRolloverWaitAttempts {
-					if !n.quietMode {
-						log.Printf("ArbiterID Critical: Clock appears stuck at %dms after %d attempts. Node ID: %d", now, attempts, n.node)
-					}
-					return 0, fmt.Errorf("%w: clock stuck at %dms after %d attempts from %dms",
-						ErrClockNotAdvancing, now, attempts, originalTime)
-				}
-
-				time.Sleep(rolloverWaitCheckInterval)
-				// Get fresh time and check if it has advanced
-				freshTime := time.Now().UTC().Sub(n.epoch).Milliseconds()
-				if freshTime > originalTime {
-					now = freshTime
-					break
-				}
-				now = freshTime
-			}
+	// Allocate a sequence, waiting for the next millisecond if this one is exhausted
+	seq, ok := n.seqAllocator.Next(now)
+	if !ok {
+		n.sequenceRollovers++
+		var err error
+		if now, err = n.waitNextMillis(ctx, now); err != nil {
+			return 0, err
 		}
-	} else {
-		n.seq = 0
+		if seq, ok = n.seqAllocator.Next(now); !ok {
+			return 0, fmt.Errorf("%w: sequence allocator exhausted at fresh millisecond %dms",
+				ErrClockNotAdvancing, now)
+		}
+	}
+	if err := n.checkFutureDrift(now, wall); err != nil {
+		return 0, err
+	}
+	if err := n.setSeq(seq); err != nil {
+		return 0, err
 	}
 
 	return n.generateInternal(idType, now)
 }
 
+// checkFutureDrift enforces WithMaxFutureDrift for an ID about to be generated at millis.
+func (n *Node) checkFutureDrift(millis, wall int64) error {
+	if n.maxFutureDrift <= 0 {
+		return nil
+	}
+	if drift := time.Duration(millis-wall) * time.Millisecond; drift > n.maxFutureDrift {
+		if !n.quietMode {
+			log.Printf("ArbiterID Warning: Generation time %dms is %s ahead of wall clock %dms (max %s). Node ID: %d", millis, drift, wall, n.maxFutureDrift, n.node)
+		}
+		return fmt.Errorf("%w: time %dms is %s ahead of wall clock %dms (max %s)",
+			ErrClockTooFarAhead, millis, drift, wall, n.maxFutureDrift)
+	}
+	return nil
+}
+
+// currentMillis reads the node's time source as milliseconds since the epoch,
+// rounded down to the configured clock granularity.
+func (n *Node) currentMillis() int64 {
+	millis := n.now().UTC().Sub(n.epoch).Milliseconds()
+	return millis - millis%n.granularity
+}
+
+// waitNextMillis sleeps until the clock moves past originalTime, returning the fresh
+// timestamp in milliseconds since epoch. It gives up after maxRolloverWaitAttempts
+// (scaled by the clock granularity, since a coarser clock advances less often), or
+// returns ctx.Err() as soon as ctx is done.
+func (n *Node) waitNextMillis(ctx context.Context, originalTime int64) (int64, error) {
+	now := originalTime
+	attempts := 0
+	for now <= originalTime {
+		attempts++
+		if int64(attempts) > maxRolloverWaitAttempts*n.granularity {
+			if !n.quietMode {
+				log.Printf("ArbiterID Critical: Clock appears stuck at %dms after %d attempts. Node ID: %d", now, attempts, n.node)
+			}
+			return 0, fmt.Errorf("%w: clock stuck at %dms after %d attempts from %dms",
+				ErrClockNotAdvancing, now, attempts, originalTime)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+		time.Sleep(rolloverWaitCheckInterval)
+		// Get fresh time and check if it has advanced
+		now = n.currentMillis()
+	}
+	return now, nil
+}
+
+// validateType rejects types that do not fit the node's type field (less the bits reserved
+// by WithRegionCode) or, with WithRegisteredTypesOnly, are not registered.
+func (n *Node) validateType(idType IDType) error {
+	limit := n.layout.typeMax()
+	if n.hasRegion {
+		limit >>= RegionBits
+	}
+	if int64(idType) > limit {
+		return fmt.Errorf("%w: got %d, max %d", ErrInvalIDType, idType, limit)
+	}
+	if n.registeredTypes != nil {
+		if _, ok := n.registeredTypes[idType]; !ok {
+			return fmt.Errorf("%w: %d", ErrUnregisteredType, idType)
+		}
+	}
+	return nil
+}
+
+// setSeq records an allocated sequence, rejecting values outside the sequence field.
+func (n *Node) setSeq(seq int64) error {
+	if seq < 0 || seq > n.layout.seqMax() {
+		return fmt.Errorf("%w: got %d, max %d", ErrInvalidSequence, seq, n.layout.seqMax())
+	}
+	n.seq = seq
+	return nil
+}
+
 // GenerateWithTimestamp creates a new unique ID with the given type and specific timestamp.
 // This method does NOT include clock rollover detection - it uses the provided timestamp as-is.
 // Use this for testing or when you need precise timestamp control.
+//
+// With strict monotonicity checks on, generating a lower type after a higher type at the
+// same timestamp is rejected, because the type bits dominate the comparison. Enable
+// WithTypeAgnosticMonotonicity to interleave types freely.
 func (n *Node) GenerateWithTimestamp(idType IDType, timestamp time.Time) (ID, error) {
-	if uint16(idType) > TypeMax {
-		return 0, fmt.Errorf("%w: got %d, max %d", ErrInvalIDType, idType, TypeMax)
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+
+	if latest := n.LatestSafeTimestamp(); timestamp.Truncate(time.Millisecond).After(latest) {
+		return 0, fmt.Errorf("%w: %s is after the latest encodable timestamp %s",
+			ErrTimestampOverflow, timestamp.UTC().Format(time.RFC3339Nano), latest.Format(time.RFC3339Nano))
 	}
 
 	n.mu.Lock()
@@ -229,21 +526,79 @@ func (n *Node) GenerateWithTimestamp(idType IDType, timestamp time.Time) (ID, er
 
 	now := timestamp.UTC().Sub(n.epoch).Milliseconds()
 
+	if n.timestampReplayGuard {
+		if now < n.maxReplayTime {
+			return 0, fmt.Errorf("%w: timestamp %dms is older than latest replayed timestamp %dms",
+				ErrTimestampReused, now, n.maxReplayTime)
+		}
+		n.maxReplayTime = now
+	}
+
 	// Handle sequence management for fixed timestamp
-	if now == n.time {
-		n.seq = (n.seq + 1) & SeqMax
-		if n.seq == 0 {
-			// Sequence exhausted - cannot advance time with fixed timestamp
-			return 0, fmt.Errorf("%w: sequence exhausted for timestamp %dms, cannot advance time with fixed timestamp",
+	seq, ok := n.seqAllocator.Next(now)
+	if !ok {
+		// Sequence exhausted - cannot advance time with fixed timestamp
+		return 0, fmt.Errorf("%w: %w: timestamp %dms, cannot advance time with fixed timestamp",
+			ErrSequenceExhausted, ErrClockNotAdvancing, now)
+	}
+	if err := n.setSeq(seq); err != nil {
+		return 0, err
+	}
+
+	return n.generateInternal(idType, now)
+}
+
+// GenerateWithTimestampAdvance is GenerateWithTimestamp for backfilling historical data:
+// instead of failing with ErrSequenceExhausted once the supplied millisecond's sequences
+// run out, it moves on to the next millisecond with a fresh sequence. A timestamp earlier
+// than the node's current time, such as the same timestamp again after an advance,
+// continues from the node's time, so repeated calls stay monotonic. The resulting
+// timestamp may therefore drift ahead of the supplied one, by one millisecond per
+// exhausted sequence space; it fails with ErrTimestampOverflow only past TimestampMax.
+func (n *Node) GenerateWithTimestampAdvance(idType IDType, timestamp time.Time) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := timestamp.UTC().Sub(n.epoch).Milliseconds()
+
+	if n.timestampReplayGuard {
+		if now < n.maxReplayTime {
+			return 0, fmt.Errorf("%w: timestamp %dms is older than latest replayed timestamp %dms",
+				ErrTimestampReused, now, n.maxReplayTime)
+		}
+		n.maxReplayTime = now
+	}
+	if now < n.time {
+		now = n.time
+	}
+
+	seq, ok := n.seqAllocator.Next(now)
+	if !ok {
+		n.sequenceRollovers++
+		now++
+		if seq, ok = n.seqAllocator.Next(now); !ok {
+			return 0, fmt.Errorf("%w: sequence allocator exhausted at fresh millisecond %dms",
 				ErrClockNotAdvancing, now)
 		}
-	} else {
-		n.seq = 0
+	}
+	if err := n.setSeq(seq); err != nil {
+		return 0, err
 	}
 
 	return n.generateInternal(idType, now)
 }
 
+// LatestSafeTimestamp returns the largest timestamp the node can encode: its epoch plus
+// TimestampMax milliseconds, roughly 69 years (less with a WithBitLayout that narrows the
+// timestamp). Generation fails with ErrTimestampOverflow past this point.
+func (n *Node) LatestSafeTimestamp() time.Time {
+	return n.epoch.Add(time.Duration(n.layout.timeMax()) * time.Millisecond)
+}
+
 // generateInternal handles the core ID generation logic.
 // Assumes sequence management and time advancement have been handled by the caller.
 // The 'now' parameter should be the timestamp in milliseconds since epoch.
@@ -251,22 +606,30 @@ func (n *Node) generateInternal(idType IDType, now int64) (ID, error) {
 
 	n.time = now
 
-	if now > TimestampMax {
+	if now > n.layout.timeMax() {
 		if !n.quietMode {
-			log.Printf("ArbiterID Critical: Timestamp %dms has overflowed TimestampMax %dms. Node ID: %d", now, TimestampMax, n.node)
+			log.Printf("ArbiterID Critical: Timestamp %dms has overflowed TimestampMax %dms. Node ID: %d", now, n.layout.timeMax(), n.node)
 		}
-		return 0, fmt.Errorf("arbiterid: timestamp %dms has overflowed maximum %dms (Epoch %s, ~69 years)",
-			now, TimestampMax, n.epoch.Format(time.RFC3339))
+		return 0, fmt.Errorf("%w: %dms exceeds maximum %dms (Epoch %s)",
+			ErrTimestampOverflow, now, n.layout.timeMax(), n.epoch.Format(time.RFC3339))
 	}
 
-	id := ID(
-		(int64(idType) << TypeShift) |
-			(now << TimeShift) |
-			(n.node << NodeShift) |
-			n.seq,
-	)
+	id := n.pack(idType, now, n.seq)
+	if id == 0 {
+		// Type 0 on node 0 at exactly the epoch packs to the zero ID, which is never valid
+		// and means "no last ID"; that one slot is skipped in favor of the next sequence.
+		seq, ok := n.seqAllocator.Next(now)
+		if !ok {
+			return 0, fmt.Errorf("%w: no sequence after the zero ID at the epoch", ErrSequenceExhausted)
+		}
+		if err := n.setSeq(seq); err != nil {
+			return 0, err
+		}
+		id = n.pack(idType, now, n.seq)
+	}
 
-	if n.strictMonotonicityChecks && id <= n.lastID {
+	if n.strictMonotonicityChecks && n.monotonicKey(id) <= n.monotonicKey(n.lastID) {
+		n.monotonicityViolations++
 		if !n.quietMode {
 			log.Printf("ArbiterID Critical: Monotonicity violation. New ID %d <= Last ID %d. Node ID: %d. Time: %d, Seq: %d", id, n.lastID, n.node, n.time, n.seq)
 		}
@@ -275,9 +638,33 @@ func (n *Node) generateInternal(idType IDType, now int64) (ID, error) {
 	}
 
 	n.lastID = id
+	n.publishLastID(id)
+	n.generated.Add(1)
+	if n.history != nil {
+		n.history.add(id)
+	}
 	return id, nil
 }
 
+// pack assembles an ID from its components using the node's ID, layout and region code.
+// The timestamp is milliseconds since the node's epoch.
+func (n *Node) pack(idType IDType, millis int64, seq int64) ID {
+	return ID(
+		(int64(n.stampType(idType)) << n.layout.typeShift()) |
+			(millis << n.layout.timeShift()) |
+			(n.node << n.layout.nodeShift()) |
+			seq,
+	)
+}
+
+// monotonicKey returns the portion of an ID compared by the strict monotonicity check.
+func (n *Node) monotonicKey(id ID) int64 {
+	if n.typeAgnosticMonotonicity {
+		return int64(id) &^ n.layout.typeMask()
+	}
+	return int64(id)
+}
+
 // GenerateSimple is a convenience method that generates an ID and panics on error.
 func (n *Node) GenerateSimple(idType IDType) ID {
 	id, err := n.Generate(idType)
@@ -290,13 +677,148 @@ func (n *Node) GenerateSimple(idType IDType) ID {
 	return id
 }
 
-// LastID returns the last ID generated by this node
+// GenerateSimpleSafe calls GenerateSimple, recovering any panic and returning it as an
+// error wrapping ErrGenerationPanicked, for callers that cannot let a panic propagate.
+func (n *Node) GenerateSimpleSafe(idType IDType) (id ID, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			id = 0
+			err = fmt.Errorf("%w: %v", ErrGenerationPanicked, r)
+		}
+	}()
+	return n.GenerateSimple(idType), nil
+}
+
+// GenerateRetry calls Generate, retrying up to retries more times after transient clock
+// errors (ErrClockNotAdvancing or ErrSequenceExhausted) with a sleep of backoff between
+// attempts. Any other error is returned immediately; if every attempt fails, the last
+// error is returned. A negative retries is treated as zero.
+func (n *Node) GenerateRetry(idType IDType, retries int, backoff time.Duration) (ID, error) {
+	for attempt := 0; ; attempt++ {
+		id, err := n.Generate(idType)
+		if err == nil {
+			return id, nil
+		}
+		if attempt >= retries || !isTransientClockError(err) {
+			return 0, err
+		}
+		if !n.quietMode {
+			log.Printf("ArbiterID Warning: Generate failed (attempt %d of %d), retrying in %s: %v", attempt+1, retries+1, backoff, err)
+		}
+		time.Sleep(backoff)
+	}
+}
+
+// isTransientClockError reports whether err may clear once the clock advances.
+func isTransientClockError(err error) bool {
+	return errors.Is(err, ErrClockNotAdvancing) || errors.Is(err, ErrSequenceExhausted)
+}
+
+// Peek returns the ID that Generate would produce for idType right now, without consuming
+// it: the node's time, sequence and last ID are left untouched. It is meant for previews
+// and tests. The next Generate may still return a greater ID if the clock advances or
+// another goroutine generates in between. When the current millisecond's sequences are
+// exhausted, Peek predicts the first ID of the next millisecond (or granule), where
+// Generate would wait for the clock. The sequence prediction assumes the default
+// allocator; with WithSequenceAllocator it is only an estimate.
+func (n *Node) Peek(idType IDType) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncLastIDLocked()
+
+	wall := n.currentMillis()
+	now := max(wall, n.time)
+	var seq int64
+	if last, lastSeq := n.allocatedLocked(); now == last && (n.lastID != 0 || n.generated.Load() != 0) {
+		if lastSeq < n.layout.seqMax() {
+			seq = lastSeq + 1
+		} else {
+			now += n.granularity
+		}
+	}
+	if a, ok := n.seqAllocator.(*incrementAllocator); ok {
+		// Generate skips sequences claimed by GenerateForKey
+		if seq = a.unclaimedFrom(now, seq); seq > n.layout.seqMax() {
+			now, seq = now+n.granularity, 0
+		}
+	}
+	if err := n.checkFutureDrift(now, wall); err != nil {
+		return 0, err
+	}
+	if now > n.layout.timeMax() {
+		return 0, fmt.Errorf("%w: %dms exceeds maximum %dms", ErrTimestampOverflow, now, n.layout.timeMax())
+	}
+
+	id := n.pack(idType, now, seq)
+	if id == 0 {
+		id = n.pack(idType, now, 1) // Generation skips the zero ID
+	}
+	if n.strictMonotonicityChecks && n.monotonicKey(id) <= n.monotonicKey(n.lastID) {
+		return 0, fmt.Errorf("%w: next ID %d (%s) <= last ID %d (%s)",
+			ErrMonotonicityViolation, id, id.TimeISO(), n.lastID, n.lastID.TimeISO())
+	}
+	return id, nil
+}
+
+// GenerateString creates a new ID and returns it as a decimal string.
+func (n *Node) GenerateString(idType IDType) (string, error) {
+	id, err := n.Generate(idType)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// GenerateBase58 creates a new ID and returns it Base58 encoded.
+func (n *Node) GenerateBase58(idType IDType) (string, error) {
+	id, err := n.Generate(idType)
+	if err != nil {
+		return "", err
+	}
+	return id.Base58(), nil
+}
+
+// GenerateBase64 creates a new ID and returns it Base64 encoded.
+func (n *Node) GenerateBase64(idType IDType) (string, error) {
+	id, err := n.Generate(idType)
+	if err != nil {
+		return "", err
+	}
+	return id.Base64(), nil
+}
+
+// GenerateOrderedString creates a new ID and returns its OrderedKey, for callers that
+// store IDs as string keys and need them to sort correctly.
+func (n *Node) GenerateOrderedString(idType IDType) (string, error) {
+	id, err := n.Generate(idType)
+	if err != nil {
+		return "", err
+	}
+	return id.OrderedKey(), nil
+}
+
+// LastID returns the last ID generated by this node.
+// With WithAtomicLastID it reads without taking the mutex.
 func (n *Node) LastID() ID {
+	if n.lockFreeLastID {
+		return ID(n.atomicLastID.Load())
+	}
 	n.mu.Lock()
 	defer n.mu.Unlock()
+	n.syncLastIDLocked()
 	return n.lastID
 }
 
+// GeneratedCount returns the number of IDs this node has successfully generated.
+// It reads an atomic counter and never blocks on the generation mutex.
+func (n *Node) GeneratedCount() int64 {
+	return n.generated.Load()
+}
+
 // Int64 returns the ID as a raw int64
 func (id ID) Int64() int64 {
 	return int64(id)
@@ -307,8 +829,14 @@ func (id ID) String() string {
 	return strconv.FormatInt(int64(id), 10)
 }
 
+// OrderedKey returns the ID as a decimal string zero-padded to 19 digits, so that keys
+// sort lexicographically in the same order as the IDs numerically. ParseString accepts it.
+func (id ID) OrderedKey() string {
+	return fmt.Sprintf("%0*d", maxDecimal, int64(id))
+}
+
 // Components extracts and returns all components of the ID.
-// Timestamp returned is milliseconds since Unix epoch.
+// Timestamp returned is milliseconds since Unix epoch, assuming the package Epoch.
 func (id ID) Components() (idType IDType, timestampMillisUnix int64, node int64, seq int64) {
 	timestampMillisNodeEpoch := (int64(id) & TimestampMask) >> TimeShift
 	timestampMillisUnix = timestampMillisNodeEpoch + Epoch
@@ -316,12 +844,41 @@ func (id ID) Components() (idType IDType, timestampMillisUnix int64, node int64,
 	return idType, timestampMillisUnix, id.Node(), id.Seq()
 }
 
+// FromComponents builds an ID from its parts without a Node, e.g. to reconstruct one stored
+// as separate columns. It is the inverse of Components: the timestamp is milliseconds since
+// the Unix epoch and must fall within the 41-bit range after the package Epoch. An out-of-range
+// component yields an ErrInvalidComponent error naming the field; the type and node errors
+// also match ErrInvalIDType and ErrInvalidNodeID.
+func FromComponents(idType IDType, timestampMillisUnix int64, node int64, seq int64) (ID, error) {
+	if uint16(idType) > TypeMax {
+		return 0, fmt.Errorf("%w: %w: type %d, max %d", ErrInvalidComponent, ErrInvalIDType, idType, TypeMax)
+	}
+	millis := timestampMillisUnix - Epoch
+	if timestampMillisUnix < Epoch || millis > TimestampMax {
+		return 0, fmt.Errorf("%w: timestamp %dms is outside [%d, %d]",
+			ErrInvalidComponent, timestampMillisUnix, Epoch, Epoch+TimestampMax)
+	}
+	if node < 0 || node > NodeMax {
+		return 0, fmt.Errorf("%w: %w: node %d, max %d", ErrInvalidComponent, ErrInvalidNodeID, node, NodeMax)
+	}
+	if seq < 0 || seq > SeqMax {
+		return 0, fmt.Errorf("%w: seq %d, max %d", ErrInvalidComponent, seq, SeqMax)
+	}
+	return ID(
+		(int64(idType) << TypeShift) |
+			(millis << TimeShift) |
+			(node << NodeShift) |
+			seq,
+	), nil
+}
+
 // Type returns the type component of the ID as int64.
 func (id ID) Type() int64 {
 	return (int64(id) & TypeMask) >> TypeShift
 }
 
-// Time returns the timestamp in Unix milliseconds
+// Time returns the timestamp in Unix milliseconds, assuming the package Epoch.
+// For IDs from a node created WithEpoch, use Node.Decoder instead.
 func (id ID) Time() int64 {
 	return ((int64(id) & TimestampMask) >> TimeShift) + Epoch
 }
@@ -336,6 +893,16 @@ func (id ID) TimeISO() string {
 	return id.TimeTime().Format(time.RFC3339Nano)
 }
 
+// TimeISOSeconds returns the timestamp in RFC 3339 format (UTC) truncated to whole seconds
+func (id ID) TimeISOSeconds() string {
+	return id.TimeTime().Format(time.RFC3339)
+}
+
+// TimeISOMillis returns the timestamp in RFC 3339 format (UTC) with exactly three fractional digits
+func (id ID) TimeISOMillis() string {
+	return id.TimeTime().Format(timeISOMillisLayout)
+}
+
 // Node returns the node component of the ID
 func (id ID) Node() int64 {
 	return (int64(id) & NodeMask) >> NodeShift
@@ -346,6 +913,23 @@ func (id ID) Seq() int64 {
 	return int64(id) & SeqMask
 }
 
+// Source returns a compact label for where the ID came from, combining its type and node
+// as "t<type>n<node>", e.g. "t512n1", for logging.
+func (id ID) Source() string {
+	buf := make([]byte, 0, 10)
+	buf = append(buf, 't')
+	buf = strconv.AppendInt(buf, id.Type(), 10)
+	buf = append(buf, 'n')
+	buf = strconv.AppendInt(buf, id.Node(), 10)
+	return string(buf)
+}
+
+// SourceKey packs the ID's type and node into one integer, type<<NodeBits | node, so IDs
+// can be grouped by origin with a single map key. Assumes the default layout.
+func (id ID) SourceKey() int64 {
+	return id.Type()<<NodeBits | id.Node()
+}
+
 // ParseString converts a decimal string to an ID
 func ParseString(s string) (ID, error) {
 	i, err := strconv.ParseInt(s, 10, 64)
@@ -369,6 +953,29 @@ func ParseBase2(s string) (ID, error) {
 	return ID(i), nil
 }
 
+// Hex returns the ID as a fixed-width, 16-character lowercase hexadecimal string.
+func (id ID) Hex() string {
+	return fmt.Sprintf("%016x", int64(id))
+}
+
+// ParseHex converts a hexadecimal string into an ID. An optional 0x prefix and upper-case
+// digits are accepted, and leading zeros may be omitted. Values with the sign bit set are
+// rejected.
+func ParseHex(s string) (ID, error) {
+	digits := s
+	if len(digits) > 2 && (digits[:2] == "0x" || digits[:2] == "0X") {
+		digits = digits[2:]
+	}
+	val, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("arbiterid: failed to parse hex string '%s': %w", s, err)
+	}
+	if val > math.MaxInt64 {
+		return 0, fmt.Errorf("arbiterid: hex value '%s' (%d) overflows positive int64 (max %d)", s, val, int64(math.MaxInt64))
+	}
+	return ID(val), nil
+}
+
 // Base32 returns the ID as a base32 string.
 func (id ID) Base32() string {
 	if id == 0 {
@@ -411,6 +1018,33 @@ func ParseBase32(s string) (ID, error) {
 	return ID(val), nil
 }
 
+// Formatted returns the ID as an upper-case base32 string grouped with dashes every
+// formattedGroupSize characters (e.g. "YBND-RFG8-EJKM"), for IDs that are read aloud or typed by hand.
+func (id ID) Formatted() string {
+	raw := strings.ToUpper(id.Base32())
+	var b strings.Builder
+	b.Grow(len(raw) + len(raw)/formattedGroupSize)
+	for i := 0; i < len(raw); i++ {
+		if i > 0 && i%formattedGroupSize == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(raw[i])
+	}
+	return b.String()
+}
+
+// ParseFormatted converts a string produced by Formatted back to an ID.
+// Dashes and spaces are ignored and letters may be in either case, so plain base32 also parses.
+func ParseFormatted(s string) (ID, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+	return ParseBase32(cleaned)
+}
+
 // Base58 returns the ID as a base58 string.
 func (id ID) Base58() string {
 	if id == 0 {
@@ -453,6 +1087,48 @@ func ParseBase58(s string) (ID, error) {
 	return ID(val), nil
 }
 
+// Base62 returns the ID as a base62 string (0-9A-Za-z).
+func (id ID) Base62() string {
+	if id == 0 {
+		return string(encodeBase62Map[0])
+	}
+	n := uint64(id)
+	buf := make([]byte, 11) // Max 11 chars for 63 bits (63/log2(62) ~ 10.6)
+	i := 10
+	for n > 0 {
+		buf[i] = encodeBase62Map[n%62]
+		n /= 62
+		i--
+	}
+	return string(buf[i+1:])
+}
+
+// ParseBase62 converts a base62 string to an ID
+func ParseBase62(s string) (ID, error) {
+	var val uint64
+	if len(s) == 0 {
+		return 0, fmt.Errorf("%w: input string is empty", ErrInvalidBase62)
+	}
+	if len(s) > 11 {
+		return 0, fmt.Errorf("%w: input string '%s' too long (max 11 chars)", ErrInvalidBase62, s)
+	}
+	for i := 0; i < len(s); i++ {
+		char := s[i]
+		decodedByte := decodeBase62Map[char]
+		if decodedByte == 0xFF {
+			return 0, fmt.Errorf("%w: invalid char '%c' in '%s'", ErrInvalidBase62, char, s)
+		}
+		if val > (math.MaxUint64-uint64(decodedByte))/62 {
+			return 0, fmt.Errorf("%w: value '%s' overflows uint64", ErrInvalidBase62, s)
+		}
+		val = val*62 + uint64(decodedByte)
+	}
+	if val > math.MaxInt64 { // Ensure it fits in positive int64
+		return 0, fmt.Errorf("%w: value '%s' overflows positive int64", ErrInvalidBase62, s)
+	}
+	return ID(val), nil
+}
+
 // Base64 returns the ID as a URL-safe base64 string.
 func (id ID) Base64() string {
 	var buf [8]byte
@@ -477,11 +1153,95 @@ func ParseBase64(s string) (ID, error) {
 	return ID(val), nil
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler, producing the 8-byte big-endian
+// form that Base64 encodes.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), uint64(id)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It requires exactly 8 bytes and
+// rejects values with the most significant bit set.
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("%w: got %d bytes", ErrBinaryInvalidLength, len(data))
+	}
+	val := binary.BigEndian.Uint64(data)
+	if val > math.MaxInt64 {
+		return fmt.Errorf("arbiterid: binary value %d overflows positive int64 (max %d)", val, int64(math.MaxInt64))
+	}
+	*id = ID(val)
+	return nil
+}
+
+// ProtoBytes returns the ID as 8 big-endian bytes for protobuf schemas that store IDs in
+// a bytes field. It is the same form as MarshalBinary; IDFromProtoBytes reverses it.
+func (id ID) ProtoBytes() []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 0, 8), uint64(id))
+}
+
+// IDFromProtoBytes decodes an ID stored by ProtoBytes. It requires exactly 8 bytes, so an
+// unset (empty) field yields ErrBinaryInvalidLength, and rejects values that are not
+// positive with ErrInvalidID.
+func IDFromProtoBytes(b []byte) (ID, error) {
+	if len(b) != 8 {
+		return 0, fmt.Errorf("%w: got %d bytes", ErrBinaryInvalidLength, len(b))
+	}
+	val := binary.BigEndian.Uint64(b)
+	if val == 0 || val > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: protobuf bytes %x are not a positive int64", ErrInvalidID, b)
+	}
+	return ID(val), nil
+}
+
+// ShortID returns the shortest of the Base58 and Base64 encodings of the ID, prefixed with
+// a marker byte naming the encoding ('5' for Base58) so ParseShortID can decode it. Base64
+// is always 11 characters while Base58 is at most 11 for 63-bit values, so Base58 is
+// always chosen; the marker leaves room for other encodings without breaking old strings.
+func (id ID) ShortID() string {
+	return string(shortIDBase58Marker) + id.Base58()
+}
+
+// ParseShortID converts a string produced by ShortID to an ID.
+func ParseShortID(s string) (ID, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("%w: '%s' too short", ErrInvalidShortID, s)
+	}
+	switch s[0] {
+	case shortIDBase58Marker:
+		return ParseBase58(s[1:])
+	default:
+		return 0, fmt.Errorf("%w: unknown encoding marker '%c' in '%s'", ErrInvalidShortID, s[0], s)
+	}
+}
+
 // MarshalJSON implements json.Marshaler
 func (id ID) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + strconv.FormatInt(int64(id), 10) + `"`), nil
 }
 
+// MarshalText implements encoding.TextMarshaler, using the same decimal form as MarshalJSON
+func (id ID) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(id), 10), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the decimal form.
+// Negative and non-numeric input is rejected.
+func (id *ID) UnmarshalText(text []byte) error {
+	if val, ok := parseDecimalBytes(text); ok {
+		*id = ID(val)
+		return nil
+	}
+	val, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return fmt.Errorf("arbiterid: failed to parse ID from text %q: %w", text, err)
+	}
+	if val < 0 {
+		return fmt.Errorf("arbiterid: parsed text ID %d is negative, expected positive value", val)
+	}
+	*id = ID(val)
+	return nil
+}
+
 // JSONSyntaxError is returned when an ID cannot be unmarshaled from JSON
 type JSONSyntaxError struct{ Original []byte }
 
@@ -491,6 +1251,42 @@ func (j JSONSyntaxError) Error() string {
 
 // UnmarshalJSON implements json.Unmarshaler
 func (id *ID) UnmarshalJSON(b []byte) error {
+	// Fast path: a quoted or bare run of digits is decoded straight from the bytes
+	// without allocating. Anything else, including errors, takes the string path.
+	digits := b
+	if len(digits) >= 2 && digits[0] == '"' && digits[len(digits)-1] == '"' {
+		digits = digits[1 : len(digits)-1]
+	}
+	if val, ok := parseDecimalBytes(digits); ok {
+		*id = ID(val)
+		return nil
+	}
+	return id.unmarshalJSONString(b)
+}
+
+// parseDecimalBytes parses b as a non-negative decimal int64. It reports false for empty
+// input, any non-digit byte, or overflow, leaving those cases to strconv.
+func parseDecimalBytes(b []byte) (int64, bool) {
+	if len(b) == 0 || len(b) > maxDecimal {
+		return 0, false
+	}
+	var val int64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		d := int64(c - '0')
+		if val > (math.MaxInt64-d)/10 {
+			return 0, false
+		}
+		val = val*10 + d
+	}
+	return val, true
+}
+
+// unmarshalJSONString is the general UnmarshalJSON path, used for input the fast path
+// does not accept so that errors are reported in full.
+func (id *ID) unmarshalJSONString(b []byte) error {
 	s := string(b)
 	var val int64
 	var err error
@@ -1,8 +1,12 @@
 package arbiterid
 
 import (
+	"context"
+	"bytes"
+	"encoding"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -305,6 +310,29 @@ func TestGenerate_TimestampOverflow(t *testing.T) {
 	}
 }
 
+func TestNode_LatestSafeTimestamp(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false))
+	latest := node.LatestSafeTimestamp()
+	if want := time.UnixMilli(Epoch + TimestampMax).UTC(); !latest.Equal(want) {
+		t.Fatalf("LatestSafeTimestamp() = %s, want %s", latest, want)
+	}
+
+	for _, ts := range []time.Time{latest, latest.Add(999 * time.Microsecond)} {
+		id, err := node.GenerateWithTimestamp(testType1, ts)
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp(%s) failed: %v", ts, err)
+		}
+		if !id.TimeTime().Equal(latest) {
+			t.Errorf("ID time %s, want %s", id.TimeTime(), latest)
+		}
+	}
+
+	_, err := node.GenerateWithTimestamp(testType1, latest.Add(time.Millisecond))
+	if !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow one millisecond past the limit, got %v", err)
+	}
+}
+
 func TestGenerate_EpochBoundaries(t *testing.T) {
 	node := newTestNode(t, testNodeID0)
 
@@ -331,6 +359,75 @@ func TestGenerate_EpochBoundaries(t *testing.T) {
 	}
 }
 
+func TestGenerate_FirstIDAtEpoch(t *testing.T) {
+	epochTime := time.UnixMilli(Epoch).UTC()
+
+	// Type 0 on node 0 at exactly the epoch would pack to the zero ID, which is also the
+	// "no last ID" sentinel; generation must skip it rather than fail or return it
+	t.Run("Generate", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(NewManualClock(epochTime)))
+		peeked, err := node.Peek(testType0)
+		if err != nil {
+			t.Fatalf("Peek at epoch failed: %v", err)
+		}
+		first, err := node.Generate(testType0)
+		if err != nil {
+			t.Fatalf("Generate at epoch failed: %v", err)
+		}
+		if first != 1 || peeked != first {
+			t.Errorf("Expected first ID 1 (peeked %d), got %d", peeked, first)
+		}
+		if first.Time() != Epoch || first.Seq() != 1 || !first.IsValid() {
+			t.Errorf("First ID %d: time %d seq %d valid %t", first, first.Time(), first.Seq(), first.IsValid())
+		}
+		if node.LastID() != first {
+			t.Errorf("LastID() = %d, want %d", node.LastID(), first)
+		}
+		second, err := node.Generate(testType0)
+		if err != nil || second != 2 {
+			t.Errorf("Expected second ID 2, got %d, %v", second, err)
+		}
+	})
+
+	t.Run("GenerateWithTimestamp", func(t *testing.T) {
+		for _, strict := range []bool{true, false} {
+			node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(strict))
+			id, err := node.GenerateWithTimestamp(testType0, epochTime)
+			if err != nil || id != 1 {
+				t.Errorf("Strict=%t: expected ID 1, got %d, %v", strict, id, err)
+			}
+		}
+	})
+
+	t.Run("GenerateBatch", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(NewManualClock(epochTime)))
+		ids, err := node.GenerateBatch(testType0, int(SeqMax)+1)
+		if err != nil {
+			t.Fatalf("GenerateBatch at epoch failed: %v", err)
+		}
+		for i, id := range ids {
+			if id <= 0 || (i > 0 && id <= ids[i-1]) {
+				t.Fatalf("ID %d (%d) is not positive and increasing", i, id)
+			}
+		}
+		// Skipping the zero ID leaves one sequence fewer in the epoch millisecond
+		if last := ids[len(ids)-1]; last.Time() != Epoch+1 || last.Seq() != 0 {
+			t.Errorf("Expected the last ID at seq 0 of the next millisecond, got %d at %d", last.Seq(), last.Time())
+		}
+	})
+
+	t.Run("Other types and nodes are unaffected", func(t *testing.T) {
+		node := newTestNode(t, testNodeID1, WithQuietMode(true))
+		if id, err := node.GenerateWithTimestamp(testType0, epochTime); err != nil || id.Seq() != 0 {
+			t.Errorf("Expected seq 0 on node 1, got %d, %v", id, err)
+		}
+		node = newTestNode(t, testNodeID0, WithQuietMode(true))
+		if id, err := node.GenerateWithTimestamp(testType1, epochTime); err != nil || id.Seq() != 0 {
+			t.Errorf("Expected seq 0 for type 1, got %d, %v", id, err)
+		}
+	})
+}
+
 func TestGenerate_AllNodeIDs(t *testing.T) {
 	// Test all valid node IDs
 	for nodeID := 0; nodeID <= int(NodeMax); nodeID++ {
@@ -904,6 +1001,55 @@ func TestGenerateSimple_Success(t *testing.T) {
 	}
 }
 
+func TestGenerateSimpleSafe(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	id, err := node.GenerateSimpleSafe(IDType(TypeMax + 1))
+	if !errors.Is(err, ErrGenerationPanicked) {
+		t.Errorf("Expected ErrGenerationPanicked, got %v", err)
+	}
+	if id != 0 {
+		t.Errorf("Expected zero ID on error, got %d", id)
+	}
+
+	id, err = node.GenerateSimpleSafe(testType1)
+	if err != nil || id == 0 {
+		t.Errorf("GenerateSimpleSafe(valid) = %d, %v; want non-zero ID and nil error", id, err)
+	}
+}
+
+func TestWithMinimumID(t *testing.T) {
+	// A legacy floor from one second ago with the highest sequence, so the
+	// floor is far above any type 0 ID
+	legacy := newTestNode(t, testNodeID1, WithQuietMode(true))
+	floorID, err := legacy.GenerateWithTimestamp(testType1, time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+	floor := floorID | ID(SeqMask)
+
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithMinimumID(floor))
+	if node.LastID() != floor {
+		t.Errorf("LastID() = %d, want floor %d", node.LastID(), floor)
+	}
+	for i := 0; i < 100; i++ {
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if id <= floor {
+			t.Fatalf("ID %d does not exceed floor %d", id, floor)
+		}
+	}
+
+	if _, err := node.Generate(testType0); !errors.Is(err, ErrMonotonicityViolation) {
+		t.Errorf("Expected a type below the floor's type to be rejected, got %v", err)
+	}
+	if _, err := NewNode(0, WithQuietMode(true), WithMinimumID(-1)); !errors.Is(err, ErrInvalidMinimumID) {
+		t.Errorf("Expected ErrInvalidMinimumID for a negative floor, got %v", err)
+	}
+}
+
 func TestLastID(t *testing.T) {
 	node := newTestNode(t, testNodeID0)
 	if node.LastID() != 0 {
@@ -919,6 +1065,111 @@ func TestLastID(t *testing.T) {
 	}
 }
 
+func TestWithAtomicLastID(t *testing.T) {
+	t.Run("TracksGeneration", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithAtomicLastID(true))
+		if node.LastID() != 0 {
+			t.Errorf("Initial LastID should be 0, got %d", node.LastID())
+		}
+		for i := 0; i < 2000; i++ {
+			id, err := node.Generate(testType1)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if got := node.LastID(); got != id {
+				t.Fatalf("LastID() = %d, want %d", got, id)
+			}
+		}
+		ids, err := node.GenerateBatch(testType1, 10)
+		if err != nil {
+			t.Fatalf("GenerateBatch failed: %v", err)
+		}
+		if got := node.LastID(); got != ids[len(ids)-1] {
+			t.Errorf("LastID() after batch = %d, want %d", got, ids[len(ids)-1])
+		}
+	})
+
+	t.Run("SeededByMinimumID", func(t *testing.T) {
+		floor := ID(12345)
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithAtomicLastID(true), WithMinimumID(floor))
+		if got := node.LastID(); got != floor {
+			t.Errorf("LastID() = %d, want floor %d", got, floor)
+		}
+	})
+
+	t.Run("ConcurrentReadsNeverGoBackwards", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithAtomicLastID(true))
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var prev ID
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				got := node.LastID()
+				if got < prev {
+					t.Errorf("LastID() went backwards: %d after %d", got, prev)
+					return
+				}
+				prev = got
+			}
+		}()
+		for i := 0; i < 5000; i++ {
+			if _, err := node.Generate(testType1); err != nil {
+				t.Errorf("Generate failed: %v", err)
+				break
+			}
+		}
+		close(done)
+		wg.Wait()
+	})
+}
+
+func TestGeneratedCount(t *testing.T) {
+	t.Run("Sequential", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		const n = 500
+		for i := 0; i < n; i++ {
+			if _, err := node.Generate(testType1); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+		}
+		// Failed generations are not counted
+		_, _ = node.Generate(IDType(TypeMax + 1))
+		if got := node.GeneratedCount(); got != n {
+			t.Errorf("GeneratedCount() = %d, want %d", got, n)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		const goroutines, perGoroutine = 8, 200
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < perGoroutine; j++ {
+					if _, err := node.Generate(testType1); err != nil {
+						t.Errorf("Generate failed: %v", err)
+						return
+					}
+					_ = node.GeneratedCount()
+				}
+			}()
+		}
+		wg.Wait()
+		if got := node.GeneratedCount(); got != goroutines*perGoroutine {
+			t.Errorf("GeneratedCount() = %d, want %d", got, goroutines*perGoroutine)
+		}
+	})
+}
+
 func TestID_Components(t *testing.T) {
 	node := newTestNode(t, testNodeID0)
 	fixedTime := time.UnixMilli(Epoch + 123456789000).UTC() // Some specific time
@@ -967,6 +1218,97 @@ func TestID_Components(t *testing.T) {
 	}
 }
 
+func TestID_Source_SourceKey(t *testing.T) {
+	ts := Epoch + 123456
+	tests := []struct {
+		idType  IDType
+		node    int64
+		wantSrc string
+	}{
+		{512, 1, "t512n1"},
+		{testType0, testNodeID0, "t0n0"},
+		{testTypeMax, NodeMax, "t1023n3"},
+	}
+	keys := make(map[int64]bool)
+	for _, tt := range tests {
+		id, err := FromComponents(tt.idType, ts, tt.node, 7)
+		if err != nil {
+			t.Fatalf("FromComponents failed: %v", err)
+		}
+		if got := id.Source(); got != tt.wantSrc {
+			t.Errorf("Source() = %q, want %q", got, tt.wantSrc)
+		}
+		wantKey := int64(tt.idType)<<NodeBits | tt.node
+		if got := id.SourceKey(); got != wantKey {
+			t.Errorf("SourceKey() for %s = %d, want %d", tt.wantSrc, got, wantKey)
+		}
+		keys[id.SourceKey()] = true
+
+		// Time and sequence do not affect the source
+		other, _ := FromComponents(tt.idType, ts+1000, tt.node, 99)
+		if other.Source() != id.Source() || other.SourceKey() != id.SourceKey() {
+			t.Errorf("Source of %s changed with time and sequence", tt.wantSrc)
+		}
+	}
+	if len(keys) != len(tests) {
+		t.Errorf("Expected %d distinct source keys, got %d", len(tests), len(keys))
+	}
+}
+
+func TestFromComponents(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		node := newTestNode(t, testNodeID1, WithQuietMode(true))
+		for _, idType := range []IDType{testType0, testType1, testTypeMax} {
+			id, err := node.Generate(idType)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			built, err := FromComponents(id.Components())
+			if err != nil {
+				t.Fatalf("FromComponents(%d.Components()) failed: %v", id, err)
+			}
+			if built != id {
+				t.Errorf("FromComponents round trip = %d, want %d", built, id)
+			}
+		}
+
+		for _, id := range []ID{0, idForEncodingTests, ID(math.MaxInt64)} {
+			if built, err := FromComponents(id.Components()); err != nil || built != id {
+				t.Errorf("FromComponents(%d.Components()) = %d, %v", id, built, err)
+			}
+		}
+	})
+
+	tests := []struct {
+		name    string
+		idType  IDType
+		ts      int64
+		node    int64
+		seq     int64
+		field   string
+		wantErr error
+	}{
+		{"TypeTooLarge", IDType(TypeMax + 1), Epoch, 0, 0, "type", ErrInvalIDType},
+		{"TimestampBeforeEpoch", testType1, Epoch - 1, 0, 0, "timestamp", ErrInvalidComponent},
+		{"TimestampOverflow", testType1, Epoch + TimestampMax + 1, 0, 0, "timestamp", ErrInvalidComponent},
+		{"NegativeNode", testType1, Epoch, -1, 0, "node", ErrInvalidNodeID},
+		{"NodeTooLarge", testType1, Epoch, NodeMax + 1, 0, "node", ErrInvalidNodeID},
+		{"NegativeSeq", testType1, Epoch, 0, -1, "seq", ErrInvalidComponent},
+		{"SeqTooLarge", testType1, Epoch, 0, SeqMax + 1, "seq", ErrInvalidComponent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromComponents(tt.idType, tt.ts, tt.node, tt.seq)
+			if !errors.Is(err, ErrInvalidComponent) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Error %q does not name the %s field", err, tt.field)
+			}
+		})
+	}
+}
+
 func TestID_TimeTime_TimeISO(t *testing.T) {
 	node := newTestNode(t, testNodeID0)
 	id, _ := node.Generate(testType1)
@@ -983,6 +1325,35 @@ func TestID_TimeTime_TimeISO(t *testing.T) {
 	}
 }
 
+func TestID_TimeISOSeconds_TimeISOMillis(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	tests := []struct {
+		name    string
+		ts      time.Time
+		seconds string
+		millis  string
+	}{
+		{"Fractional", time.Date(2025, 3, 4, 5, 6, 7, 89_000_000, time.UTC), "2025-03-04T05:06:07Z", "2025-03-04T05:06:07.089Z"},
+		{"Whole second", time.Date(2025, 3, 4, 5, 6, 8, 0, time.UTC), "2025-03-04T05:06:08Z", "2025-03-04T05:06:08.000Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := node.GenerateWithTimestamp(testType1, tt.ts)
+			if err != nil {
+				t.Fatalf("GenerateWithTimestamp failed: %v", err)
+			}
+			if got := id.TimeISOSeconds(); got != tt.seconds {
+				t.Errorf("TimeISOSeconds() = %s, want %s", got, tt.seconds)
+			}
+			if got := id.TimeISOMillis(); got != tt.millis {
+				t.Errorf("TimeISOMillis() = %s, want %s", got, tt.millis)
+			}
+		})
+	}
+}
+
 func TestID_Int64(t *testing.T) {
 	node := newTestNode(t, testNodeID0)
 	id, _ := node.Generate(testType1)
@@ -1066,6 +1437,33 @@ func TestID_Base2_ParseBase2(t *testing.T) {
 	}
 }
 
+func TestID_Hex_ParseHex(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	for _, id := range []ID{0, ID(math.MaxInt64), idForEncodingTests, node.GenerateSimple(testType1)} {
+		s := id.Hex()
+		if len(s) != 16 || strings.ToLower(s) != s {
+			t.Errorf("Hex(%d) = %q, want 16 lowercase digits", id, s)
+		}
+		for _, in := range []string{s, "0x" + s, strings.ToUpper(s)} {
+			if parsed, err := ParseHex(in); err != nil || parsed != id {
+				t.Errorf("ParseHex(%q) = %d, %v; want %d", in, parsed, err, id)
+			}
+		}
+	}
+	if got := ID(0).Hex(); got != "0000000000000000" {
+		t.Errorf("Hex(0) = %q", got)
+	}
+	if got, err := ParseHex("0x1f"); err != nil || got != 31 {
+		t.Errorf("ParseHex(0x1f) = %d, %v; want 31", got, err)
+	}
+
+	for _, bad := range []string{"", "0x", "xyz", "12g4", "-1", "8000000000000000", "1ffffffffffffffff"} {
+		if _, err := ParseHex(bad); err == nil {
+			t.Errorf("ParseHex(%q) should fail", bad)
+		}
+	}
+}
+
 func TestID_Base32_ParseBase32(t *testing.T) {
 	idsToTest := []ID{0, 1, 31, 32, idForEncodingTests, ID(SeqMax), ID(int64(TypeMax)<<TypeShift | SeqMax), ID(math.MaxInt64)}
 	for _, originalID := range idsToTest {
@@ -1108,6 +1506,291 @@ func TestID_Base32_ParseBase32(t *testing.T) {
 	}
 }
 
+func TestID_OrderedKey(t *testing.T) {
+	for _, id := range []ID{0, 7, idForEncodingTests, ID(math.MaxInt64)} {
+		key := id.OrderedKey()
+		if len(key) != 19 {
+			t.Errorf("OrderedKey(%d) = %q, want 19 digits", id, key)
+		}
+		if parsed, err := ParseString(key); err != nil || parsed != id {
+			t.Errorf("ParseString(%q) = %d, %v; want %d", key, parsed, err, id)
+		}
+	}
+}
+
+func TestGenerateRetry(t *testing.T) {
+	// stuckFor simulates a clock that is stuck for the first k Generate calls, failing them
+	// as a real stuck clock would without spending the full rollover wait in each
+	stuckFor := func(k int64, calls *atomic.Int64) NodeOption {
+		return WithGenerateMiddleware(func(next GenerateFunc) GenerateFunc {
+			return func(idType IDType) (ID, error) {
+				if calls.Add(1) <= k {
+					return 0, fmt.Errorf("%w: clock stuck", ErrClockNotAdvancing)
+				}
+				return next(idType)
+			}
+		})
+	}
+
+	t.Run("SucceedsOnceClockAdvances", func(t *testing.T) {
+		var calls atomic.Int64
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), stuckFor(2, &calls))
+		id, err := node.GenerateRetry(testType1, 3, time.Millisecond)
+		if err != nil {
+			t.Fatalf("GenerateRetry failed: %v", err)
+		}
+		if id != node.LastID() || calls.Load() != 3 {
+			t.Errorf("GenerateRetry = %d after %d attempts; want LastID %d after 3", id, calls.Load(), node.LastID())
+		}
+	})
+
+	t.Run("ExhaustsRetries", func(t *testing.T) {
+		var calls atomic.Int64
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), stuckFor(10, &calls))
+		if _, err := node.GenerateRetry(testType1, 2, time.Millisecond); !errors.Is(err, ErrClockNotAdvancing) {
+			t.Errorf("Expected ErrClockNotAdvancing after retries, got %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Errorf("GenerateRetry made %d attempts, want 3", calls.Load())
+		}
+	})
+
+	t.Run("PermanentErrorNotRetried", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		start := time.Now()
+		if _, err := node.GenerateRetry(IDType(TypeMax+1), 5, time.Second); !errors.Is(err, ErrInvalIDType) {
+			t.Errorf("Expected ErrInvalIDType, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Permanent error was retried: took %s", elapsed)
+		}
+	})
+}
+
+func TestGenerateContext(t *testing.T) {
+	clock := NewManualClock(time.Now().UTC().Truncate(time.Millisecond))
+	var calls int
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(clock),
+		WithGenerateMiddleware(func(next GenerateFunc) GenerateFunc {
+			return func(idType IDType) (ID, error) {
+				calls++
+				return next(idType)
+			}
+		}))
+
+	// Exhaust the current millisecond; the stalled clock makes the next call wait
+	if _, err := node.GenerateBatch(testType1, int(SeqMax)+1); err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	last := node.LastID()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	id, err := node.GenerateContext(ctx, testType1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %d, %v", id, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GenerateContext took %s to notice the deadline", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("Expected middleware to run once, ran %d times", calls)
+	}
+	if node.LastID() != last {
+		t.Errorf("Abandoned wait changed LastID from %d to %d", last, node.LastID())
+	}
+
+	clock.Advance(time.Millisecond)
+	id, err = node.GenerateContext(context.Background(), testType1)
+	if err != nil {
+		t.Fatalf("GenerateContext failed after the clock advanced: %v", err)
+	}
+	if id <= last || id.Seq() != 0 {
+		t.Errorf("Expected first ID of the next millisecond after %d, got %d (seq %d)", last, id, id.Seq())
+	}
+
+	if _, err := node.GenerateContext(context.Background(), IDType(TypeMax+1)); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType, got %v", err)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	clock := NewManualClock(time.UnixMilli(Epoch + 123_456).UTC())
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(clock))
+
+	peekThenGenerate := func() {
+		t.Helper()
+		lastBefore := node.LastID()
+		peeked, err := node.Peek(testType1)
+		if err != nil {
+			t.Fatalf("Peek failed: %v", err)
+		}
+		if again, _ := node.Peek(testType1); again != peeked {
+			t.Errorf("Repeated Peek = %d, want %d", again, peeked)
+		}
+		if node.LastID() != lastBefore {
+			t.Errorf("Peek changed LastID from %d to %d", lastBefore, node.LastID())
+		}
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		// The clock is frozen, so the prediction is exact
+		if id != peeked {
+			t.Errorf("Generate = %d (seq %d), Peek predicted %d (seq %d)", id, id.Seq(), peeked, peeked.Seq())
+		}
+	}
+
+	// First ID, subsequent ones in the same millisecond, and after the clock advances
+	peekThenGenerate()
+	peekThenGenerate()
+	clock.Advance(time.Millisecond)
+	peekThenGenerate()
+
+	// Once the millisecond is exhausted, Peek predicts the next one; with a frozen clock
+	// Generate would wait, so advance it as a real clock would
+	for node.LastID().Seq() < SeqMax {
+		if _, err := node.Generate(testType1); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+	peeked, err := node.Peek(testType1)
+	if err != nil {
+		t.Fatalf("Peek failed: %v", err)
+	}
+	if peeked.Seq() != 0 || peeked.Time() != node.LastID().Time()+1 {
+		t.Errorf("Peek after exhaustion = time %d seq %d, want time %d seq 0",
+			peeked.Time(), peeked.Seq(), node.LastID().Time()+1)
+	}
+	clock.Advance(time.Millisecond)
+	if id, err := node.Generate(testType1); err != nil || id < peeked {
+		t.Errorf("Generate after Peek = %d, %v; want >= %d", id, err, peeked)
+	}
+
+	if _, err := node.Peek(IDType(TypeMax + 1)); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType, got %v", err)
+	}
+}
+
+func TestGenerateEncoded(t *testing.T) {
+	node := newTestNode(t, testNodeID1, WithQuietMode(true))
+	tests := []struct {
+		name     string
+		generate func(IDType) (string, error)
+		encoding EncodingKind
+	}{
+		{"String", node.GenerateString, EncodingDecimal},
+		{"Base58", node.GenerateBase58, EncodingBase58},
+		{"Base64", node.GenerateBase64, EncodingBase64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.generate(testTypeMax)
+			if err != nil {
+				t.Fatalf("Generate%s failed: %v", tt.name, err)
+			}
+			id, err := ParseAndValidate(s, tt.encoding)
+			if err != nil {
+				t.Fatalf("Generated %q does not decode as %s: %v", s, tt.encoding, err)
+			}
+			if id.Type() != int64(testTypeMax) || id.Node() != testNodeID1 {
+				t.Errorf("Decoded ID has type %d, node %d; want %d, %d", id.Type(), id.Node(), testTypeMax, testNodeID1)
+			}
+			if id != node.LastID() {
+				t.Errorf("Decoded ID %d is not the node's last ID %d", id, node.LastID())
+			}
+
+			if _, err := tt.generate(IDType(TypeMax + 1)); !errors.Is(err, ErrInvalIDType) {
+				t.Errorf("Expected ErrInvalIDType, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateOrderedString(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	// A small ID has fewer digits than generated ones, so plain decimal strings would
+	// sort it after them
+	keys := []string{ID(42).OrderedKey()}
+	ids := []ID{42}
+	for i := 0; i < 20; i++ {
+		key, err := node.GenerateOrderedString(testType1)
+		if err != nil {
+			t.Fatalf("GenerateOrderedString failed: %v", err)
+		}
+		id, err := ParseString(key)
+		if err != nil {
+			t.Fatalf("ParseString(%q) failed: %v", key, err)
+		}
+		keys = append(keys, key)
+		ids = append(ids, id)
+	}
+
+	sorted := append([]string(nil), keys...)
+	sort.Sort(sort.Reverse(sort.StringSlice(sorted)))
+	sort.Strings(sorted)
+	for i := range keys {
+		if sorted[i] != keys[i] {
+			t.Fatalf("Ordered keys do not sort in generation order: %v", sorted)
+		}
+	}
+	if _, err := VerifyMonotonic(ids); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := node.GenerateOrderedString(IDType(TypeMax + 1)); err == nil {
+		t.Error("Expected error for invalid type")
+	}
+}
+
+func TestID_Formatted_ParseFormatted(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	generated := node.GenerateSimple(testType1)
+
+	for _, id := range []ID{0, 1, 31, 32, generated, ID(math.MaxInt64)} {
+		formatted := id.Formatted()
+		for i, group := range strings.Split(formatted, "-") {
+			if len(group) > 4 || (len(group) < 4 && i != strings.Count(formatted, "-")) {
+				t.Errorf("Formatted(%d) = %s has a malformed group %q", id, formatted, group)
+			}
+		}
+		if formatted != strings.ToUpper(formatted) {
+			t.Errorf("Formatted(%d) = %s should be upper case", id, formatted)
+		}
+
+		inputs := []string{
+			formatted,
+			strings.ToLower(formatted),
+			strings.ReplaceAll(formatted, "-", ""),
+			strings.ReplaceAll(formatted, "-", " "),
+			id.Base32(),
+		}
+		for _, input := range inputs {
+			parsed, err := ParseFormatted(input)
+			if err != nil {
+				t.Errorf("ParseFormatted(%q) failed: %v", input, err)
+				continue
+			}
+			if parsed != id {
+				t.Errorf("ParseFormatted(%q) = %d, want %d", input, parsed, id)
+			}
+		}
+	}
+
+	if got := ID(1).Formatted(); got != "B" {
+		t.Errorf("Formatted(1) = %s, want B", got)
+	}
+	if got, want := ID(math.MaxInt64).Formatted(), strings.ToUpper(ID(math.MaxInt64).Base32()); strings.ReplaceAll(got, "-", "") != want || len(got) != 16 {
+		t.Errorf("Formatted(MaxInt64) = %s, want 13 characters of %s in groups of 4", got, want)
+	}
+
+	if _, err := ParseFormatted("YBND-RFG8-EJK!"); !errors.Is(err, ErrInvalidBase32) {
+		t.Errorf("Expected ErrInvalidBase32 for invalid character, got %v", err)
+	}
+}
+
 func TestID_Base58_ParseBase58(t *testing.T) {
 	idsToTest := []ID{0, 1, 57, 58, idForEncodingTests, ID(SeqMax), ID(int64(TypeMax)<<TypeShift | SeqMax), ID(math.MaxInt64)}
 	for _, originalID := range idsToTest {
@@ -1150,6 +1833,52 @@ func TestID_Base58_ParseBase58(t *testing.T) {
 	}
 }
 
+func TestID_Base62_ParseBase62(t *testing.T) {
+	idsToTest := []ID{0, 1, 61, 62, idForEncodingTests, ID(SeqMax), ID(int64(TypeMax)<<TypeShift | SeqMax), ID(math.MaxInt64)}
+	for _, originalID := range idsToTest {
+		t.Run(fmt.Sprintf("ID_%d", originalID), func(t *testing.T) {
+			s := originalID.Base62()
+			if len(s) == 0 || len(s) > 11 {
+				t.Errorf("Base62 string has bad length: %s (len %d)", s, len(s))
+			}
+			if len(s) > len(originalID.Base58()) {
+				t.Errorf("Base62 string %s is longer than Base58 %s", s, originalID.Base58())
+			}
+			parsedID, err := ParseBase62(s)
+			if err != nil {
+				t.Fatalf("ParseBase62(%s) failed: %v", s, err)
+			}
+			if parsedID != originalID {
+				t.Errorf("ParseBase62: for ID %d, expected %d, got %d from string '%s'", originalID, originalID, parsedID, s)
+			}
+		})
+	}
+
+	if got := ID(math.MaxInt64).Base62(); got != "AzL8n0Y58m7" {
+		t.Errorf("Base62(MaxInt64) = %s, want AzL8n0Y58m7", got)
+	}
+
+	// Test error cases
+	errorCases := []struct {
+		name  string
+		input string
+	}{
+		{"invalid chars", "abc-_"},
+		{"empty string", ""},
+		{"too long", strings.Repeat("1", 12)},
+		{"overflows uint64", strings.Repeat("z", 11)},
+		{"overflows int64", "AzL8n0Y58m8"},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseBase62(tc.input); !errors.Is(err, ErrInvalidBase62) {
+				t.Errorf("ParseBase62 should fail with ErrInvalidBase62 for %s: %s, got %v", tc.name, tc.input, err)
+			}
+		})
+	}
+}
+
 func TestID_Base64_ParseBase64(t *testing.T) {
 	idsToTest := []ID{0, 1, idForEncodingTests, ID(SeqMax), ID(int64(TypeMax)<<TypeShift | SeqMax), ID(math.MaxInt64)}
 	for _, originalID := range idsToTest {
@@ -1189,6 +1918,158 @@ func TestID_Base64_ParseBase64(t *testing.T) {
 	}
 }
 
+func TestID_ShortID_ParseShortID(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ids := []ID{0, 1, 57, 58, node.GenerateSimple(testType0), node.GenerateSimple(testTypeMax), ID(math.MaxInt64)}
+
+	for _, id := range ids {
+		short := id.ShortID()
+
+		shortest := min(len(id.Base58()), len(id.Base64()))
+		if len(short) != shortest+1 {
+			t.Errorf("ShortID(%d) = %q has length %d, want shortest encoding (%d) plus marker", id, short, len(short), shortest)
+		}
+		if short[0] != shortIDBase58Marker || short[1:] != id.Base58() {
+			t.Errorf("ShortID(%d) = %q, want marker %q and Base58 %q", id, short, shortIDBase58Marker, id.Base58())
+		}
+
+		parsed, err := ParseShortID(short)
+		if err != nil {
+			t.Errorf("ParseShortID(%q) failed: %v", short, err)
+			continue
+		}
+		if parsed != id {
+			t.Errorf("ParseShortID(%q) = %d, want %d", short, parsed, id)
+		}
+	}
+
+	for _, input := range []string{"", "5", "x123", "5!!", "6" + ID(12345).Base64()} {
+		if _, err := ParseShortID(input); err == nil {
+			t.Errorf("ParseShortID(%q) expected error", input)
+		}
+	}
+	if _, err := ParseShortID("x123"); !errors.Is(err, ErrInvalidShortID) {
+		t.Errorf("Expected ErrInvalidShortID for unknown marker, got %v", err)
+	}
+}
+
+func TestID_UnmarshalJSON_FastPathMatchesStringPath(t *testing.T) {
+	inputs := []string{
+		`"0"`, `0`, `"42"`, `"0042"`, `1234567890123456789`,
+		`"9223372036854775807"`, `"9223372036854775808"`, `"99999999999999999999"`,
+		`""`, `"`, `"+5"`, `"-5"`, `-5`, `"12a"`, `null`, `"1_000"`, ` 5`,
+	}
+	for _, input := range inputs {
+		var fast, slow ID
+		fastErr := fast.UnmarshalJSON([]byte(input))
+		slowErr := slow.unmarshalJSONString([]byte(input))
+		if (fastErr == nil) != (slowErr == nil) || fast != slow {
+			t.Errorf("UnmarshalJSON(%s) = %d, %v; string path gives %d, %v", input, fast, fastErr, slow, slowErr)
+		}
+	}
+}
+
+func TestID_BinaryMarshaling(t *testing.T) {
+	var _ encoding.BinaryMarshaler = ID(0)
+	var _ encoding.BinaryUnmarshaler = (*ID)(nil)
+
+	for _, id := range []ID{0, 1, idForEncodingTests, ID(math.MaxInt64)} {
+		data, err := id.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %v", err)
+		}
+		if len(data) != 8 {
+			t.Fatalf("MarshalBinary(%d) produced %d bytes", id, len(data))
+		}
+		if base64.RawURLEncoding.EncodeToString(data) != id.Base64() {
+			t.Errorf("MarshalBinary(%d) = %x, not the bytes Base64 encodes", id, data)
+		}
+		var parsed ID
+		if err := parsed.UnmarshalBinary(data); err != nil || parsed != id {
+			t.Errorf("UnmarshalBinary(%x) = %d, %v; want %d", data, parsed, err, id)
+		}
+	}
+
+	// Binary encoders such as gob pick up the methods transparently
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(idForEncodingTests); err != nil {
+		t.Fatalf("gob encode failed: %v", err)
+	}
+	var decoded ID
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil || decoded != idForEncodingTests {
+		t.Errorf("gob round trip = %d, %v; want %d", decoded, err, idForEncodingTests)
+	}
+
+	var id ID
+	if err := id.UnmarshalBinary([]byte{1, 2, 3}); !errors.Is(err, ErrBinaryInvalidLength) {
+		t.Errorf("Expected ErrBinaryInvalidLength, got %v", err)
+	}
+	if err := id.UnmarshalBinary([]byte{0x80, 0, 0, 0, 0, 0, 0, 1}); err == nil {
+		t.Error("Expected error for value with the most significant bit set")
+	}
+}
+
+func TestID_ProtoBytes(t *testing.T) {
+	for _, id := range []ID{1, idForEncodingTests, ID(math.MaxInt64)} {
+		b := id.ProtoBytes()
+		if len(b) != 8 {
+			t.Fatalf("ProtoBytes(%d) returned %d bytes, want 8", id, len(b))
+		}
+		if marshaled, _ := id.MarshalBinary(); !bytes.Equal(b, marshaled) {
+			t.Errorf("ProtoBytes(%d) = %x, want MarshalBinary form %x", id, b, marshaled)
+		}
+		parsed, err := IDFromProtoBytes(b)
+		if err != nil {
+			t.Fatalf("IDFromProtoBytes(%x) failed: %v", b, err)
+		}
+		if parsed != id {
+			t.Errorf("IDFromProtoBytes(%x) = %d, want %d", b, parsed, id)
+		}
+	}
+
+	for _, b := range [][]byte{nil, {}, make([]byte, 7), make([]byte, 9)} {
+		if _, err := IDFromProtoBytes(b); !errors.Is(err, ErrBinaryInvalidLength) {
+			t.Errorf("IDFromProtoBytes(%d bytes): expected ErrBinaryInvalidLength, got %v", len(b), err)
+		}
+	}
+	for _, id := range []ID{0, -1, ID(math.MinInt64)} {
+		if _, err := IDFromProtoBytes(id.ProtoBytes()); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("IDFromProtoBytes(%d): expected ErrInvalidID, got %v", id, err)
+		}
+	}
+}
+
+func TestID_TextMarshaling(t *testing.T) {
+	var _ encoding.TextMarshaler = ID(0)
+	var _ encoding.TextUnmarshaler = (*ID)(nil)
+
+	for _, id := range []ID{0, 1, idForEncodingTests, ID(math.MaxInt64)} {
+		text, err := id.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText failed: %v", err)
+		}
+		if string(text) != id.String() {
+			t.Errorf("MarshalText(%d) = %q, want %q", id, text, id.String())
+		}
+		jsonForm, _ := id.MarshalJSON()
+		if `"`+string(text)+`"` != string(jsonForm) {
+			t.Errorf("MarshalText %q disagrees with MarshalJSON %s", text, jsonForm)
+		}
+
+		var parsed ID
+		if err := parsed.UnmarshalText(text); err != nil || parsed != id {
+			t.Errorf("UnmarshalText(%q) = %d, %v; want %d", text, parsed, err, id)
+		}
+	}
+
+	for _, input := range []string{"", "-1", "abc", "12x", "9223372036854775808", idForEncodingTests.Base58()} {
+		var id ID
+		if err := id.UnmarshalText([]byte(input)); err == nil {
+			t.Errorf("UnmarshalText(%q) expected error, got %d", input, id)
+		}
+	}
+}
+
 func TestID_JSON_MarshalUnmarshal(t *testing.T) {
 	idsToTest := []ID{0, 1, idForEncodingTests, ID(math.MaxInt64)}
 
@@ -1248,6 +2129,217 @@ func TestID_JSON_MarshalUnmarshal(t *testing.T) {
 	}
 }
 
+func TestGenerateWithTimestampAdvance(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ts := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	perMilli := int(SeqMax) + 1
+
+	var last ID
+	for i := 0; i < 3*perMilli+5; i++ {
+		id, err := node.GenerateWithTimestampAdvance(testType1, ts)
+		if err != nil {
+			t.Fatalf("ID %d: GenerateWithTimestampAdvance failed: %v", i, err)
+		}
+		if id <= last {
+			t.Fatalf("ID %d: %d is not greater than %d", i, id, last)
+		}
+		if want := ts.Add(time.Duration(i/perMilli) * time.Millisecond); !id.TimeTime().Equal(want) {
+			t.Fatalf("ID %d: timestamp %s, want %s", i, id.TimeISO(), want.Format(time.RFC3339Nano))
+		}
+		if want := int64(i % perMilli); id.Seq() != want {
+			t.Fatalf("ID %d: seq %d, want %d", i, id.Seq(), want)
+		}
+		last = id
+	}
+
+	// The plain variant still refuses to leave its millisecond
+	fixed := newTestNode(t, testNodeID0, WithQuietMode(true))
+	for i := 0; i < perMilli; i++ {
+		if _, err := fixed.GenerateWithTimestamp(testType1, ts); err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+	}
+	if _, err := fixed.GenerateWithTimestamp(testType1, ts); !errors.Is(err, ErrSequenceExhausted) {
+		t.Errorf("Expected ErrSequenceExhausted, got %v", err)
+	}
+	if id, err := fixed.GenerateWithTimestampAdvance(testType1, ts); err != nil || !id.TimeTime().Equal(ts.Add(time.Millisecond)) {
+		t.Errorf("Expected an ID 1ms after %s, got %d (%s), %v", ts, id, id.TimeISO(), err)
+	}
+
+	// Only the end of the timestamp range stops it
+	end := newTestNode(t, testNodeID0, WithQuietMode(true))
+	latest := end.LatestSafeTimestamp()
+	for i := 0; i < perMilli; i++ {
+		if _, err := end.GenerateWithTimestampAdvance(testType1, latest); err != nil {
+			t.Fatalf("GenerateWithTimestampAdvance at the latest timestamp failed: %v", err)
+		}
+	}
+	if _, err := end.GenerateWithTimestampAdvance(testType1, latest); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow past TimestampMax, got %v", err)
+	}
+}
+
+func TestGenerateWithTimestamp_ReplayGuard(t *testing.T) {
+	base := time.Now().UTC().Truncate(time.Millisecond)
+	replay := []time.Time{base, base, base.Add(2 * time.Millisecond), base.Add(time.Millisecond)}
+
+	t.Run("Guard enabled", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false), WithTimestampReplayGuard(true))
+		for i, ts := range replay[:3] {
+			if _, err := node.GenerateWithTimestamp(testType1, ts); err != nil {
+				t.Fatalf("GenerateWithTimestamp failed at step %d: %v", i, err)
+			}
+		}
+		if _, err := node.GenerateWithTimestamp(testType1, replay[3]); !errors.Is(err, ErrTimestampReused) {
+			t.Errorf("Expected ErrTimestampReused for out-of-order timestamp, got %v", err)
+		}
+		if _, err := node.GenerateWithTimestamp(testType1, replay[2]); err != nil {
+			t.Errorf("Repeating the latest timestamp should succeed, got %v", err)
+		}
+	})
+
+	t.Run("Guard disabled", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false))
+		for i, ts := range replay {
+			if _, err := node.GenerateWithTimestamp(testType1, ts); err != nil {
+				t.Fatalf("GenerateWithTimestamp failed at step %d: %v", i, err)
+			}
+		}
+	})
+}
+
+func TestGenerateWithTimestamp_TypeAgnosticMonotonicity(t *testing.T) {
+	fixedTime := time.Now().UTC().Add(time.Minute)
+	types := []IDType{5, 1, 1023, 0, 7, 2}
+
+	t.Run("Type agnostic enabled", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithTypeAgnosticMonotonicity(true))
+		seen := make(map[ID]bool)
+		var lastSeq int64 = -1
+		for i, typ := range types {
+			id, err := node.GenerateWithTimestamp(typ, fixedTime)
+			if err != nil {
+				t.Fatalf("GenerateWithTimestamp failed for type %d at iteration %d: %v", typ, i, err)
+			}
+			if seen[id] {
+				t.Errorf("Duplicate ID generated: %d", id)
+			}
+			seen[id] = true
+			if IDType(id.Type()) != typ {
+				t.Errorf("Expected type %d, got %d", typ, id.Type())
+			}
+			if id.Seq() <= lastSeq {
+				t.Errorf("Sequence did not advance: %d <= %d", id.Seq(), lastSeq)
+			}
+			lastSeq = id.Seq()
+		}
+	})
+
+	t.Run("Type agnostic disabled", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		if _, err := node.GenerateWithTimestamp(types[0], fixedTime); err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		_, err := node.GenerateWithTimestamp(types[1], fixedTime)
+		if !errors.Is(err, ErrMonotonicityViolation) {
+			t.Errorf("Expected ErrMonotonicityViolation for lower type after higher type, got %v", err)
+		}
+	})
+}
+
+func TestGenerate_WithNowFunc(t *testing.T) {
+	fixed := time.Date(2025, 6, 1, 12, 0, 0, 123_000_000, time.UTC)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return fixed }))
+
+	for i := 0; i < 10; i++ {
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed at iteration %d: %v", i, err)
+		}
+		if !id.TimeTime().Equal(fixed) {
+			t.Errorf("Expected timestamp %s, got %s", fixed.Format(time.RFC3339Nano), id.TimeISO())
+		}
+		if id.Seq() != int64(i) {
+			t.Errorf("Expected sequence %d, got %d", i, id.Seq())
+		}
+	}
+}
+
+func TestGenerate_MaxFutureDrift(t *testing.T) {
+	fixed := time.Now().UTC().Truncate(time.Millisecond)
+	nowFunc := WithNowFunc(func() time.Time { return fixed })
+
+	t.Run("Within drift", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc, WithMaxFutureDrift(10*time.Millisecond))
+		if _, err := node.GenerateWithTimestamp(testType1, fixed.Add(5*time.Millisecond)); err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		if _, err := node.Generate(testType1); err != nil {
+			t.Errorf("Generate within drift failed: %v", err)
+		}
+	})
+
+	t.Run("Beyond drift", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc, WithMaxFutureDrift(10*time.Millisecond))
+		// Force the internal time an hour ahead of the wall clock
+		if _, err := node.GenerateWithTimestamp(testType1, fixed.Add(time.Hour)); err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		if _, err := node.Generate(testType1); !errors.Is(err, ErrClockTooFarAhead) {
+			t.Errorf("Expected ErrClockTooFarAhead, got %v", err)
+		}
+		if _, err := node.GenerateBatch(testType1, 1); !errors.Is(err, ErrBatchTooLarge) {
+			t.Errorf("Expected ErrBatchTooLarge for batch beyond drift, got %v", err)
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), nowFunc)
+		if _, err := node.GenerateWithTimestamp(testType1, fixed.Add(time.Hour)); err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		if _, err := node.Generate(testType1); err != nil {
+			t.Errorf("Generate without drift limit failed: %v", err)
+		}
+	})
+}
+
+func TestGenerate_ClockGranularity(t *testing.T) {
+	const granularity = 10 * time.Millisecond
+	clock := NewManualClock(time.UnixMilli(Epoch + 123_457).UTC())
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(clock), WithClockGranularity(granularity))
+
+	assertGranular := func(id ID) {
+		t.Helper()
+		if (id.Time()-Epoch)%granularity.Milliseconds() != 0 {
+			t.Errorf("ID %d timestamp %d is not a multiple of %s from the epoch", id, id.Time(), granularity)
+		}
+	}
+
+	var last ID
+	for i := 0; i < 25; i++ {
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed at iteration %d: %v", i, err)
+		}
+		assertGranular(id)
+		if id <= last {
+			t.Errorf("ID %d should exceed previous ID %d", id, last)
+		}
+		last = id
+		clock.Advance(3 * time.Millisecond)
+	}
+
+	// A batch larger than one granule's sequence space steps a whole granule at a time
+	ids, err := node.GenerateBatch(testType1, int(SeqMax)*2)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	for _, id := range ids {
+		assertGranular(id)
+	}
+}
+
 // --- Performance Tests ---
 
 func TestID_EncodingPerformance(t *testing.T) {
@@ -1506,3 +2598,41 @@ func BenchmarkID_UnmarshalJSON(b *testing.B) {
 		_ = json.Unmarshal(benchJSONBytes, &id)
 	}
 }
+
+func BenchmarkID_UnmarshalJSON_FastPath(b *testing.B) {
+	var id ID
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = id.UnmarshalJSON(benchJSONBytes)
+	}
+}
+
+func BenchmarkID_UnmarshalJSON_StringPath(b *testing.B) {
+	var id ID
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = id.unmarshalJSONString(benchJSONBytes)
+	}
+}
+
+func BenchmarkLastID_ConcurrentWithGenerate(b *testing.B) {
+	for _, lockFree := range []bool{false, true} {
+		b.Run(fmt.Sprintf("AtomicLastID=%t", lockFree), func(b *testing.B) {
+			node, err := NewNode(0, WithQuietMode(true), WithAtomicLastID(lockFree))
+			if err != nil {
+				b.Fatalf("NewNode() error = %v", err)
+			}
+			var i atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				// One in eight iterations generates; the rest poll LastID
+				for pb.Next() {
+					if i.Add(1)%8 == 0 {
+						_, _ = node.Generate(testType1)
+					} else {
+						_ = node.LastID()
+					}
+				}
+			})
+		})
+	}
+}
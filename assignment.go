@@ -0,0 +1,47 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateNodeID is returned by ValidateNodeAssignment when a node ID is assigned more than once.
+var ErrDuplicateNodeID = errors.New("arbiterid: node ID assigned more than once")
+
+// ValidateNodeAssignment checks a deployment's list of node IDs, one per instance, before
+// it goes live: every ID must be within 0..NodeMax, and no two instances may share one.
+// All conflicts are reported together; the returned error matches ErrInvalidNodeID and/or
+// ErrDuplicateNodeID with errors.Is. It returns nil for a valid assignment.
+func ValidateNodeAssignment(assignments []int) error {
+	var errs []error
+	firstIndex := make(map[int]int, len(assignments))
+	reported := make(map[int]bool)
+
+	for i, nodeID := range assignments {
+		if int64(nodeID) < 0 || int64(nodeID) > NodeMax {
+			errs = append(errs, fmt.Errorf("%w: instance %d has node ID %d, max %d", ErrInvalidNodeID, i, nodeID, NodeMax))
+			continue
+		}
+		first, seen := firstIndex[nodeID]
+		if !seen {
+			firstIndex[nodeID] = i
+			continue
+		}
+		if !reported[nodeID] {
+			reported[nodeID] = true
+			errs = append(errs, fmt.Errorf("%w: node ID %d used by instances %v", ErrDuplicateNodeID, nodeID, instancesWith(assignments, nodeID, first)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// instancesWith returns the indexes at or after from whose value is nodeID.
+func instancesWith(assignments []int, nodeID, from int) []int {
+	var idx []int
+	for i := from; i < len(assignments); i++ {
+		if assignments[i] == nodeID {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
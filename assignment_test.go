@@ -0,0 +1,31 @@
+package arbiterid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateNodeAssignment(t *testing.T) {
+	if err := ValidateNodeAssignment([]int{0, 1, 2, 3}); err != nil {
+		t.Errorf("Valid assignment rejected: %v", err)
+	}
+	if err := ValidateNodeAssignment(nil); err != nil {
+		t.Errorf("Empty assignment rejected: %v", err)
+	}
+
+	err := ValidateNodeAssignment([]int{0, 2, 2, 7})
+	if !errors.Is(err, ErrDuplicateNodeID) {
+		t.Errorf("Expected ErrDuplicateNodeID, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidNodeID) {
+		t.Errorf("Expected ErrInvalidNodeID, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "node ID 2 used by instances [1 2]") || !strings.Contains(msg, "instance 3 has node ID 7") {
+		t.Errorf("Error does not describe the conflicts: %q", msg)
+	}
+
+	if err := ValidateNodeAssignment([]int{1, -1}); !errors.Is(err, ErrInvalidNodeID) || errors.Is(err, ErrDuplicateNodeID) {
+		t.Errorf("Expected only ErrInvalidNodeID, got %v", err)
+	}
+}
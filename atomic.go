@@ -0,0 +1,73 @@
+package arbiterid
+
+// GenerateAtomic creates a new unique ID like Generate, but takes the common case of
+// another sequence in the current millisecond with a compare-and-swap on the default
+// allocator's packed time and sequence instead of the node's mutex. Clock rollover,
+// sequence exhaustion, and backwards clock moves fall back to Generate's locked path,
+// which shares the same allocator state, so IDs from both paths are unique and their
+// (timestamp, sequence) portion strictly increases in the order they are allocated.
+//
+// The lock-free path publishes each ID with a compare-and-swap on the node's atomic copy
+// of the last ID, so its IDs count towards GeneratedCount and show up in LastID (with or
+// without WithAtomicLastID), Snapshot, Describe, and State like those of Generate.
+// Because it cannot compare types with the last ID, it is only taken when the strict monotonicity check is off or type-agnostic
+// (see WithTypeAgnosticMonotonicity), the node uses the default sequence allocator, and
+// neither WithRecentHistory nor WithGenerateMiddleware is set; otherwise every call takes
+// the locked path.
+func (n *Node) GenerateAtomic(idType IDType) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+
+	if a, ok := n.seqAllocator.(*incrementAllocator); ok && n.lockFreeEligible() {
+		now := n.currentMillis()
+		if seq, ok := a.nextInMillis(now); ok {
+			id := n.pack(idType, now, seq)
+			n.publishLastID(id)
+			n.generated.Add(1)
+			return id, nil
+		}
+	}
+	return n.Generate(idType)
+}
+
+// lockFreeEligible reports whether GenerateAtomic may skip the mutex. The fields it reads
+// are only set by options, so no locking is needed.
+func (n *Node) lockFreeEligible() bool {
+	return (!n.strictMonotonicityChecks || n.typeAgnosticMonotonicity) && n.history == nil && n.generate == nil
+}
+
+// publishLastID records id in atomicLastID. The lock-free path publishes after allocating,
+// so concurrent IDs can arrive out of allocation order; while it may run, an ID only
+// replaces one that is earlier in (timestamp, sequence).
+func (n *Node) publishLastID(id ID) {
+	if !n.lockFreeEligible() {
+		n.atomicLastID.Store(int64(id))
+		return
+	}
+	mask := n.layout.typeMask()
+	for {
+		last := n.atomicLastID.Load()
+		if last&^mask >= int64(id)&^mask || n.atomicLastID.CompareAndSwap(last, int64(id)) {
+			return
+		}
+	}
+}
+
+// syncLastIDLocked folds IDs published by the lock-free path, which leaves the
+// mutex-guarded fields alone, into lastID and seq. Readers of those fields call it with
+// the node's mutex held.
+func (n *Node) syncLastIDLocked() {
+	if !n.lockFreeEligible() {
+		return
+	}
+	last := ID(n.atomicLastID.Load())
+	mask := n.layout.typeMask()
+	if int64(last)&^mask <= int64(n.lastID)&^mask {
+		return
+	}
+	n.lastID = last
+	if int64(last)>>n.layout.timeShift()&n.layout.timeMax() == n.time {
+		n.seq = max(n.seq, int64(last)&n.layout.seqMax())
+	}
+}
@@ -0,0 +1,141 @@
+package arbiterid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestGenerateAtomic(t *testing.T) {
+	t.Run("ConcurrentUniqueAndIncreasing", func(t *testing.T) {
+		node := newTestNode(t, testNodeID1, WithQuietMode(true), WithTypeAgnosticMonotonicity(true))
+		const goroutines, perGoroutine = 8, 3000
+
+		results := make([][]ID, goroutines)
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				// Mix both paths so they contend for the same allocator state
+				for i := 0; i < perGoroutine; i++ {
+					var (
+						id  ID
+						err error
+					)
+					if i%10 == 0 {
+						id, err = node.Generate(testType1)
+					} else {
+						id, err = node.GenerateAtomic(testType1)
+					}
+					if err != nil {
+						t.Errorf("Generate failed: %v", err)
+						return
+					}
+					results[g] = append(results[g], id)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		seen := make(map[ID]bool, goroutines*perGoroutine)
+		for _, ids := range results {
+			// Each goroutine observes its own IDs in increasing order
+			if _, err := VerifyMonotonic(ids); err != nil {
+				t.Error(err)
+			}
+			for _, id := range ids {
+				if seen[id] {
+					t.Fatalf("Duplicate ID %d", id)
+				}
+				seen[id] = true
+				if id.Node() != testNodeID1 || id.Type() != int64(testType1) {
+					t.Fatalf("ID %d has node %d, type %d", id, id.Node(), id.Type())
+				}
+			}
+		}
+		if got := node.GeneratedCount(); got != goroutines*perGoroutine {
+			t.Errorf("GeneratedCount() = %d, want %d", got, goroutines*perGoroutine)
+		}
+	})
+
+	t.Run("LastID", func(t *testing.T) {
+		for _, atomicLastID := range []bool{false, true} {
+			node := newTestNode(t, testNodeID1, WithQuietMode(true), WithTypeAgnosticMonotonicity(true),
+				WithAtomicLastID(atomicLastID))
+			var (
+				latest ID
+				mu     sync.Mutex
+				wg     sync.WaitGroup
+			)
+			for g := 0; g < 4; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 2000; i++ {
+						id, err := node.GenerateAtomic(testType1)
+						if err != nil {
+							t.Errorf("GenerateAtomic failed: %v", err)
+							return
+						}
+						mu.Lock()
+						latest = max(latest, id)
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := node.LastID(); got != latest {
+				t.Errorf("WithAtomicLastID(%t): LastID() = %d, want the latest ID %d", atomicLastID, got, latest)
+			}
+			if snap := node.Snapshot(); snap.LastID != latest || snap.Seq != latest.Seq() {
+				t.Errorf("WithAtomicLastID(%t): Snapshot() = %+v, want last ID %d", atomicLastID, snap, latest)
+			}
+		}
+	})
+
+	t.Run("TypeSensitiveNodeUsesLockedPath", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		if _, err := node.GenerateAtomic(testTypeMax); err != nil {
+			t.Fatalf("GenerateAtomic failed: %v", err)
+		}
+		// The default type-sensitive check still applies
+		if _, err := node.GenerateAtomic(testType0); !errors.Is(err, ErrMonotonicityViolation) {
+			t.Errorf("Expected ErrMonotonicityViolation, got %v", err)
+		}
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false))
+		if _, err := node.GenerateAtomic(IDType(TypeMax + 1)); !errors.Is(err, ErrInvalIDType) {
+			t.Errorf("Expected ErrInvalIDType, got %v", err)
+		}
+	})
+}
+
+func BenchmarkGenerateAtomic_Parallel(b *testing.B) {
+	for _, tt := range []struct {
+		name     string
+		generate func(*Node) func(IDType) (ID, error)
+	}{
+		{"Mutex", func(n *Node) func(IDType) (ID, error) { return n.Generate }},
+		{"Atomic", func(n *Node) func(IDType) (ID, error) { return n.GenerateAtomic }},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			// With the default 10 sequence bits both variants are capped at ~1M IDs/s by the
+			// sequence space; 16 bits leave room for lock contention to show
+			node := newTestNode(b, testNodeID0, WithQuietMode(true), WithTypeAgnosticMonotonicity(true),
+				WithBitLayout(6, 2, 16))
+			generate := tt.generate(node)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := generate(testType1); err != nil {
+						b.Errorf("Generate failed: %v", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package arbiterid
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GenerateBatch creates count unique IDs of the given type in a single lock acquisition.
+//
+// When count exceeds the sequence space remaining in the current millisecond, the batch
+// is laid out across as many subsequent milliseconds as needed up front instead of
+// sleeping for the clock to advance. This makes large batches fast, but the trailing IDs
+// may be future-dated by up to count/(SeqMax+1) milliseconds. Subsequent Generate calls
+// continue from the batch's last timestamp, so they stay unique and ordered. A batch that
+// would run more than one second (or the WithMaxFutureDrift limit, if set) ahead of the
+// wall clock is rejected with ErrBatchTooLarge. Custom sequence allocators that hand out
+// fewer sequences per millisecond spread the batch further.
+func (n *Node) GenerateBatch(idType IDType, count int) ([]ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidBatchCount, count)
+	}
+
+	ids := make([]ID, count)
+	if _, err := n.fillBatch(idType, ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GenerateBatchRange is GenerateBatch that also returns the first and last IDs of the
+// batch, e.g. to record the range a bulk insert covers as an index hint. Batch IDs
+// increase, so these are also the smallest and largest; on error all three results are
+// zero.
+func (n *Node) GenerateBatchRange(idType IDType, count int) (ids []ID, first, last ID, err error) {
+	ids, err = n.GenerateBatch(idType, count)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return ids, ids[0], ids[len(ids)-1], nil
+}
+
+// GenerateInto fills dst with unique IDs of the given type and returns how many were
+// written, which is len(dst) unless an error occurs. It follows the same up-front
+// multi-millisecond layout and limits as GenerateBatch but allocates nothing, so a hot
+// loop can reuse one buffer. On error, the returned count of leading entries in dst
+// holds the IDs generated before the failure.
+func (n *Node) GenerateInto(idType IDType, dst []ID) (int, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+	if len(dst) == 0 {
+		return 0, nil
+	}
+	return n.fillBatch(idType, dst)
+}
+
+// GenerateN creates count unique IDs of the given type while holding the node's mutex
+// once, applying exactly the per-ID logic of Generate: sequences increment within a
+// millisecond, and when one is exhausted it waits for the clock to advance instead of
+// future-dating IDs as GenerateBatch does. If an error such as a stalled clock or
+// timestamp overflow occurs partway, the IDs generated so far are returned with it.
+func (n *Node) GenerateN(idType IDType, count int) ([]ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidBatchCount, count)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ids := make([]ID, count)
+	for i := range ids {
+		id, err := n.generateLocked(context.Background(), idType)
+		if err != nil {
+			return ids[:i], err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// GenerateDistinctMillis creates count unique IDs of the given type, each in its own
+// millisecond (or clock granule, see WithClockGranularity): the opposite of GenerateBatch,
+// which packs IDs into as few milliseconds as possible. Rather than waiting for the clock,
+// the internal time is advanced by one millisecond per ID, so the trailing IDs are
+// future-dated by up to count-1 milliseconds. Counts that would run further ahead of the
+// wall clock than GenerateBatch allows are rejected with ErrBatchTooLarge.
+func (n *Node) GenerateDistinctMillis(idType IDType, count int) ([]ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidBatchCount, count)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	wall := n.currentMillis()
+	if err := n.checkRestoredClock(wall); err != nil {
+		return nil, err
+	}
+	now := wall
+	if now < n.time {
+		now = n.time
+	}
+	if err := n.checkBatchDrift(now+int64(count-1)*n.granularity, wall, count); err != nil {
+		return nil, err
+	}
+
+	ids := make([]ID, count)
+	for i := range ids {
+		seq, ok := n.seqAllocator.Next(now)
+		for !ok {
+			n.sequenceRollovers++
+			// The first millisecond may already be exhausted by earlier IDs
+			now += n.granularity
+			if err := n.checkBatchDrift(now, wall, count); err != nil {
+				return nil, err
+			}
+			seq, ok = n.seqAllocator.Next(now)
+		}
+		if err := n.setSeq(seq); err != nil {
+			return nil, err
+		}
+		id, err := n.generateInternal(idType, now)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+		now += n.granularity
+	}
+	return ids, nil
+}
+
+// fillBatch generates len(dst) IDs into dst under a single lock acquisition and returns
+// how many were written.
+func (n *Node) fillBatch(idType IDType, dst []ID) (int, error) {
+	count := len(dst)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	wall := n.currentMillis()
+	if err := n.checkRestoredClock(wall); err != nil {
+		return 0, err
+	}
+	now := wall
+	if now < n.time {
+		// Clock moved backwards (or a previous batch ran ahead); continue from the last time
+		now = n.time
+	}
+
+	// The default allocator packs SeqMax+1 IDs into each millisecond (or granule), so this
+	// is the fewest the batch can occupy; reject it before touching any state.
+	if err := n.checkBatchDrift(now+int64(count-1)/(n.layout.seqMax()+1)*n.granularity, wall, count); err != nil {
+		return 0, err
+	}
+
+	for i := range dst {
+		seq, ok := n.seqAllocator.Next(now)
+		for !ok {
+			n.sequenceRollovers++
+			now += n.granularity
+			if err := n.checkBatchDrift(now, wall, count); err != nil {
+				return i, err
+			}
+			seq, ok = n.seqAllocator.Next(now)
+		}
+		if err := n.setSeq(seq); err != nil {
+			return i, err
+		}
+		id, err := n.generateInternal(idType, now)
+		if err != nil {
+			return i, err
+		}
+		dst[i] = id
+	}
+	return count, nil
+}
+
+// checkBatchDrift rejects batches that would reach millis too far ahead of the wall clock.
+func (n *Node) checkBatchDrift(millis, wall int64, count int) error {
+	limit := n.maxFutureAdvance()
+	if drift := time.Duration(millis-wall) * time.Millisecond; drift > limit {
+		return fmt.Errorf("%w: %d IDs would reach %dms, %s ahead of wall clock (max %s)",
+			ErrBatchTooLarge, count, millis, drift, limit)
+	}
+	return nil
+}
+
+// maxFutureAdvance returns how far the internal time may be deliberately advanced past
+// the wall clock: the WithMaxFutureDrift limit if set, otherwise defaultMaxFutureAdvance.
+func (n *Node) maxFutureAdvance() time.Duration {
+	if n.maxFutureDrift > 0 {
+		return n.maxFutureDrift
+	}
+	return defaultMaxFutureAdvance
+}
@@ -0,0 +1,236 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateBatch_LargeBatchSpansMilliseconds(t *testing.T) {
+	fixed := time.Now().UTC().Truncate(time.Millisecond)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return fixed }))
+
+	const count = 5000
+	ids, err := node.GenerateBatch(testType1, count)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	if len(ids) != count {
+		t.Fatalf("Expected %d IDs, got %d", count, len(ids))
+	}
+
+	seen := make(map[ID]bool, count)
+	for i, id := range ids {
+		if seen[id] {
+			t.Errorf("Duplicate ID generated: %d at index %d", id, i)
+		}
+		seen[id] = true
+	}
+	if _, err := VerifyMonotonic(ids); err != nil {
+		t.Error(err)
+	}
+
+	// 5000 IDs starting at seq 0 fill four full milliseconds and part of a fifth
+	expectedSpan := int64(count-1) / (SeqMax + 1)
+	if first := ids[0].Time(); first != fixed.UnixMilli() {
+		t.Errorf("Expected first timestamp %d, got %d", fixed.UnixMilli(), first)
+	}
+	if span := ids[count-1].Time() - ids[0].Time(); span != expectedSpan {
+		t.Errorf("Expected batch to span %dms, got %dms", expectedSpan, span)
+	}
+
+	// Generation after the batch must continue past it
+	next, err := node.Generate(testType1)
+	if err != nil {
+		t.Fatalf("Generate after batch failed: %v", err)
+	}
+	if next <= ids[count-1] {
+		t.Errorf("ID after batch %d should exceed last batch ID %d", next, ids[count-1])
+	}
+}
+
+func TestGenerateBatch_InvalidInput(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	if _, err := node.GenerateBatch(testType1, 0); !errors.Is(err, ErrInvalidBatchCount) {
+		t.Errorf("Expected ErrInvalidBatchCount for zero count, got %v", err)
+	}
+	if _, err := node.GenerateBatch(IDType(TypeMax+1), 1); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType, got %v", err)
+	}
+
+	tooMany := int((defaultMaxFutureAdvance/time.Millisecond)+2) * int(SeqMax+1)
+	if _, err := node.GenerateBatch(testType1, tooMany); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Expected ErrBatchTooLarge for %d IDs, got %v", tooMany, err)
+	}
+}
+
+func TestGenerateBatchRange(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	ids, lo, hi, err := node.GenerateBatchRange(testType1, 1500)
+	if err != nil {
+		t.Fatalf("GenerateBatchRange failed: %v", err)
+	}
+	if len(ids) != 1500 {
+		t.Fatalf("Expected 1500 IDs, got %d", len(ids))
+	}
+	for _, id := range ids {
+		if id < lo || id > hi {
+			t.Errorf("ID %d outside reported range [%d, %d]", id, lo, hi)
+		}
+	}
+	if lo != ids[0] || hi != ids[len(ids)-1] {
+		t.Errorf("Range [%d, %d] does not match batch ends [%d, %d]", lo, hi, ids[0], ids[len(ids)-1])
+	}
+
+	ids, lo, hi, err = node.GenerateBatchRange(testType1, 0)
+	if !errors.Is(err, ErrInvalidBatchCount) || ids != nil || lo != 0 || hi != 0 {
+		t.Errorf("Expected ErrInvalidBatchCount with zero results, got %v, %d, %d, %v", ids, lo, hi, err)
+	}
+}
+
+func TestGenerateInto(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	dst := make([]ID, 3000)
+	written, err := node.GenerateInto(testType1, dst)
+	if err != nil {
+		t.Fatalf("GenerateInto failed: %v", err)
+	}
+	if written != len(dst) {
+		t.Fatalf("GenerateInto wrote %d IDs, want %d", written, len(dst))
+	}
+	for i, id := range dst {
+		if id == 0 {
+			t.Fatalf("Slot %d was not filled", i)
+		}
+		if i > 0 && id <= dst[i-1] {
+			t.Errorf("ID not monotonically increasing: %d <= %d at index %d", id, dst[i-1], i)
+		}
+		if IDType(id.Type()) != testType1 {
+			t.Errorf("Expected type %d at index %d, got %d", testType1, i, id.Type())
+		}
+	}
+
+	// Reusing the buffer continues after the previous contents
+	last := dst[len(dst)-1]
+	if _, err := node.GenerateInto(testType1, dst[:10]); err != nil {
+		t.Fatalf("GenerateInto on reused buffer failed: %v", err)
+	}
+	if dst[0] <= last {
+		t.Errorf("Reused buffer ID %d should exceed previous last ID %d", dst[0], last)
+	}
+
+	if written, err := node.GenerateInto(testType1, nil); written != 0 || err != nil {
+		t.Errorf("GenerateInto(nil) = %d, %v; want 0, nil", written, err)
+	}
+}
+
+func BenchmarkGenerateInto(b *testing.B) {
+	clock := NewManualClock(time.Now())
+	node := newTestNode(b, testNodeID0, WithQuietMode(true), WithManualClock(clock))
+	dst := make([]ID, 64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Keep the batch layout from running ahead of the clock
+		clock.Advance(time.Millisecond)
+		if _, err := node.GenerateInto(testType1, dst); err != nil {
+			b.Fatalf("GenerateInto failed: %v", err)
+		}
+	}
+}
+
+func TestGenerateDistinctMillis(t *testing.T) {
+	fixed := time.Now()
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return fixed }))
+	first := node.GenerateSimple(testType1)
+
+	const count = 50
+	ids, err := node.GenerateDistinctMillis(testType1, count)
+	if err != nil {
+		t.Fatalf("GenerateDistinctMillis failed: %v", err)
+	}
+	if len(ids) != count {
+		t.Fatalf("Expected %d IDs, got %d", count, len(ids))
+	}
+	if ids[0] <= first {
+		t.Errorf("First distinct ID %d not greater than earlier ID %d", ids[0], first)
+	}
+	if _, err := VerifyMonotonic(ids); err != nil {
+		t.Error(err)
+	}
+
+	millis := make(map[int64]bool, count)
+	for _, id := range ids {
+		if millis[id.Time()] {
+			t.Errorf("Timestamp %d shared by more than one ID", id.Time())
+		}
+		millis[id.Time()] = true
+	}
+
+	if _, err := node.GenerateDistinctMillis(testType1, 5000); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Expected ErrBatchTooLarge for 5000 distinct milliseconds, got %v", err)
+	}
+	if _, err := node.GenerateDistinctMillis(testType1, 0); !errors.Is(err, ErrInvalidBatchCount) {
+		t.Errorf("Expected ErrInvalidBatchCount, got %v", err)
+	}
+}
+
+func TestGenerateN(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	const count = 3000 // More than fits in one millisecond, so rollover must happen
+	ids, err := node.GenerateN(testType1, count)
+	if err != nil {
+		t.Fatalf("GenerateN failed: %v", err)
+	}
+	if len(ids) != count {
+		t.Fatalf("Expected %d IDs, got %d", count, len(ids))
+	}
+	if _, err := VerifyMonotonic(ids); err != nil {
+		t.Error(err)
+	}
+	for i := 1; i < count; i++ {
+		prev, cur := ids[i-1], ids[i]
+		if cur.Time() == prev.Time() && cur.Seq() != prev.Seq()+1 {
+			t.Fatalf("Sequence jumped from %d to %d within %dms", prev.Seq(), cur.Seq(), cur.Time())
+		}
+		if cur.Time() != prev.Time() && cur.Seq() != 0 {
+			t.Fatalf("Sequence %d did not restart at 0 after rollover to %dms", cur.Seq(), cur.Time())
+		}
+	}
+	if now := time.Now().UnixMilli(); ids[count-1].Time() > now {
+		t.Errorf("GenerateN future-dated an ID: %d > %d", ids[count-1].Time(), now)
+	}
+
+	if _, err := node.GenerateN(testType1, 0); !errors.Is(err, ErrInvalidBatchCount) {
+		t.Errorf("Expected ErrInvalidBatchCount, got %v", err)
+	}
+}
+
+func TestGenerateN_PartialOnOverflow(t *testing.T) {
+	// The clock reaches the end of the timestamp range after five IDs
+	reads := 0
+	last := time.UnixMilli(Epoch + TimestampMax)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time {
+		reads++
+		if reads > 5 {
+			return last.Add(time.Millisecond)
+		}
+		return last
+	}))
+
+	ids, err := node.GenerateN(testType1, 10)
+	if !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow, got %v", err)
+	}
+	if len(ids) != 5 {
+		t.Fatalf("Expected the 5 IDs generated before the overflow, got %d", len(ids))
+	}
+	if ids[len(ids)-1] != node.LastID() {
+		t.Errorf("Last returned ID %d, node's last ID %d", ids[len(ids)-1], node.LastID())
+	}
+}
@@ -0,0 +1,95 @@
+package arbiterid
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoManualClock is returned by Node.AdvanceClock when the node was not created with WithManualClock.
+var ErrNoManualClock = errors.New("arbiterid: node has no manual clock to advance")
+
+// ManualClock is a controllable time source for tests. It only moves when told to.
+// It is safe for concurrent use.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock returns a ManualClock reading start.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the clock's current time
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock by d. A negative d moves it backwards.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Clock is a source of the current time for a Node, for injecting deterministic time in
+// tests. ManualClock implements it.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real-time Clock nodes use by default.
+type SystemClock struct{}
+
+// Now returns time.Now()
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// WithClock makes Generate and the node's other methods read time from c. Giving a
+// *ManualClock is the same as WithManualClock, so Node.AdvanceClock can drive it. A nil
+// clock is ignored; the default is SystemClock.
+func WithClock(c Clock) NodeOption {
+	if mc, ok := c.(*ManualClock); ok {
+		return WithManualClock(mc)
+	}
+	return func(n *Node) {
+		if c != nil {
+			n.now = c.Now
+			n.manualClock = nil
+		}
+	}
+}
+
+// WithManualClock makes Generate read time from c, which the test can then drive with
+// Node.AdvanceClock or the clock's own methods. A nil clock is ignored.
+func WithManualClock(c *ManualClock) NodeOption {
+	return func(n *Node) {
+		if c != nil {
+			n.manualClock = c
+			n.now = c.Now
+		}
+	}
+}
+
+// AdvanceClock moves the node's manual clock by d. A negative d moves it backwards, which
+// Generate handles like a real clock rollback: it keeps using its last millisecond until
+// the clock catches up. It returns ErrNoManualClock unless the node was created with
+// WithManualClock, since the real clock cannot be moved.
+func (n *Node) AdvanceClock(d time.Duration) error {
+	if n.manualClock == nil {
+		return ErrNoManualClock
+	}
+	n.manualClock.Advance(d)
+	return nil
+}
@@ -0,0 +1,136 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNode_AdvanceClock(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(NewManualClock(start)))
+
+	first := node.GenerateSimple(testType1)
+	if !first.TimeTime().Equal(start) {
+		t.Errorf("Expected first ID at %s, got %s", start, first.TimeISO())
+	}
+
+	steps := []time.Duration{time.Millisecond, 5 * time.Millisecond, time.Second}
+	expected := start
+	last := first
+	for _, step := range steps {
+		if err := node.AdvanceClock(step); err != nil {
+			t.Fatalf("AdvanceClock(%s) failed: %v", step, err)
+		}
+		expected = expected.Add(step)
+		id := node.GenerateSimple(testType1)
+		if !id.TimeTime().Equal(expected) {
+			t.Errorf("After advancing %s expected ID at %s, got %s", step, expected, id.TimeISO())
+		}
+		if id.Seq() != 0 {
+			t.Errorf("Expected sequence to reset after advancing, got %d", id.Seq())
+		}
+		if id <= last {
+			t.Errorf("ID %d should exceed previous ID %d", id, last)
+		}
+		last = id
+	}
+}
+
+func TestNode_AdvanceClock_Backwards(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(NewManualClock(start)))
+
+	first := node.GenerateSimple(testType1)
+	if err := node.AdvanceClock(-time.Second); err != nil {
+		t.Fatalf("AdvanceClock(-1s) failed: %v", err)
+	}
+	second := node.GenerateSimple(testType1)
+	if !second.TimeTime().Equal(start) {
+		t.Errorf("Expected ID to stay at %s while the clock is behind, got %s", start, second.TimeISO())
+	}
+	if second <= first {
+		t.Errorf("ID %d should exceed previous ID %d", second, first)
+	}
+	if got := node.Snapshot().ClockWarningCount; got != 1 {
+		t.Errorf("Expected 1 clock warning, got %d", got)
+	}
+}
+
+func TestNode_AdvanceClock_NoManualClock(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	if err := node.AdvanceClock(time.Second); !errors.Is(err, ErrNoManualClock) {
+		t.Errorf("Expected ErrNoManualClock, got %v", err)
+	}
+}
+
+// scriptedClock replays a fixed list of times, repeating the last one once exhausted.
+type scriptedClock struct {
+	times []time.Time
+	calls int
+}
+
+func (c *scriptedClock) Now() time.Time {
+	t := c.times[min(c.calls, len(c.times)-1)]
+	c.calls++
+	return t
+}
+
+func TestWithClock(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Backwards clock", func(t *testing.T) {
+		clock := &scriptedClock{times: []time.Time{start, start.Add(-time.Second)}}
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithClock(clock))
+
+		first := node.GenerateSimple(testType1)
+		second := node.GenerateSimple(testType1)
+		if second <= first || second.Time() != first.Time() {
+			t.Errorf("Expected %d to follow %d in the same millisecond", second, first)
+		}
+		if got := node.Snapshot().ClockWarningCount; got != 1 {
+			t.Errorf("Expected one clock warning, got %d", got)
+		}
+	})
+
+	t.Run("Sequence exhaustion waits for the clock", func(t *testing.T) {
+		// One read per ID for a full millisecond, then a few stalled reads while
+		// waiting for rollover, then the next millisecond
+		times := make([]time.Time, 0, SeqMax+6)
+		for i := int64(0); i <= SeqMax+3; i++ {
+			times = append(times, start)
+		}
+		clock := &scriptedClock{times: append(times, start.Add(time.Millisecond))}
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithClock(clock))
+
+		for i := int64(0); i <= SeqMax; i++ {
+			node.GenerateSimple(testType1)
+		}
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate after exhaustion failed: %v", err)
+		}
+		if !id.TimeTime().Equal(start.Add(time.Millisecond)) || id.Seq() != 0 {
+			t.Errorf("Expected seq 0 at %s, got seq %d at %s", start.Add(time.Millisecond), id.Seq(), id.TimeISO())
+		}
+	})
+
+	t.Run("Manual clock", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithClock(NewManualClock(start)))
+		if err := node.AdvanceClock(time.Second); err != nil {
+			t.Errorf("AdvanceClock failed for a ManualClock given to WithClock: %v", err)
+		}
+		if got := node.GenerateSimple(testType1).TimeTime(); !got.Equal(start.Add(time.Second)) {
+			t.Errorf("Expected ID at %s, got %s", start.Add(time.Second), got)
+		}
+	})
+
+	t.Run("Nil and system clock", func(t *testing.T) {
+		for _, c := range []Clock{nil, SystemClock{}} {
+			node := newTestNode(t, testNodeID0, WithQuietMode(true), WithClock(c))
+			if d := time.Since(node.GenerateSimple(testType1).TimeTime()); d < 0 || d > time.Second {
+				t.Errorf("Expected an ID near the current time with clock %v, got one %s old", c, d)
+			}
+		}
+	})
+}
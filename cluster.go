@@ -0,0 +1,85 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Cluster errors
+var (
+	ErrEmptyCluster       = errors.New("arbiterid: cluster has no nodes")
+	ErrDuplicateClusterID = errors.New("arbiterid: cluster nodes must have distinct node IDs")
+)
+
+// Cluster groups nodes with distinct node IDs, typically in one process, so that
+// generation can fall back to another node instead of blocking on one whose sequence
+// space is used up for the current millisecond.
+type Cluster struct {
+	nodes []*Node
+}
+
+// NewCluster returns a Cluster over nodes, tried in the given order. At least one node is
+// required, and no two may share a node ID, or their IDs could collide.
+func NewCluster(nodes ...*Node) (*Cluster, error) {
+	if len(nodes) == 0 {
+		return nil, ErrEmptyCluster
+	}
+	seen := make(map[int64]bool, len(nodes))
+	for _, n := range nodes {
+		if seen[n.node] {
+			return nil, fmt.Errorf("%w: node ID %d appears more than once", ErrDuplicateClusterID, n.node)
+		}
+		seen[n.node] = true
+	}
+	return &Cluster{nodes: append([]*Node(nil), nodes...)}, nil
+}
+
+// Nodes returns the cluster's nodes in the order they are tried.
+func (c *Cluster) Nodes() []*Node {
+	return append([]*Node(nil), c.nodes...)
+}
+
+// GenerateAvailable creates a new ID on the first node, in order, that still has sequence
+// capacity in the current millisecond according to RemainingSequence. If every node is
+// exhausted, it falls back to the first node, which waits for the next millisecond as
+// Generate does. Another goroutine may use up a node's capacity between the check and the
+// generation, in which case that call also waits rather than failing.
+func (c *Cluster) GenerateAvailable(idType IDType) (ID, error) {
+	for _, n := range c.nodes {
+		if n.RemainingSequence() > 0 {
+			return n.Generate(idType)
+		}
+	}
+	return c.nodes[0].Generate(idType)
+}
+
+// RemainingSequence returns how many more IDs Generate can issue in the current
+// millisecond (or clock granule) without waiting for the clock to advance. It assumes the
+// default sequence allocator, which hands out SeqMax+1 sequences per millisecond; with a
+// custom allocator it is only an estimate. Sequences claimed by GenerateForKey are not
+// counted. The value may be stale by the time it is used if other goroutines share the node.
+func (n *Node) RemainingSequence() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.currentMillis()
+	remaining, last := n.layout.seqMax()+1, int64(-1)
+	if millis, seq := n.allocatedLocked(); now <= millis {
+		now, last = millis, seq
+		remaining = n.layout.seqMax() - last
+	}
+	if a, ok := n.seqAllocator.(*incrementAllocator); ok {
+		remaining -= a.claimedAfter(now, last)
+	}
+	return remaining
+}
+
+// SkewBetween returns how far a's embedded timestamp is ahead of b's, negative if it is
+// behind, as a rough clock-skew indicator between two nodes. Pass each node's latest ID,
+// e.g. from polling LastID, taken at about the same moment: the result also includes how
+// long ago each node last generated, so it is only meaningful for busy nodes. Both IDs
+// must share an epoch.
+func SkewBetween(a, b ID) time.Duration {
+	return time.Duration(a.Time()-b.Time()) * time.Millisecond
+}
@@ -0,0 +1,105 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCluster_GenerateAvailable(t *testing.T) {
+	fixed := time.Now()
+	clock := func() time.Time { return fixed }
+	first := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(clock))
+	second := newTestNode(t, testNodeID1, WithQuietMode(true), WithNowFunc(clock))
+
+	cluster, err := NewCluster(first, second)
+	if err != nil {
+		t.Fatalf("NewCluster failed: %v", err)
+	}
+
+	if got := first.RemainingSequence(); got != SeqMax+1 {
+		t.Errorf("Fresh node RemainingSequence = %d, want %d", got, SeqMax+1)
+	}
+	id, err := cluster.GenerateAvailable(testType1)
+	if err != nil {
+		t.Fatalf("GenerateAvailable failed: %v", err)
+	}
+	if id.Node() != testNodeID0 {
+		t.Errorf("Expected the first node to be used, got node %d", id.Node())
+	}
+
+	// Saturate the first node's sequence space for the pinned millisecond
+	for first.RemainingSequence() > 0 {
+		first.GenerateSimple(testType1)
+	}
+
+	id, err = cluster.GenerateAvailable(testType1)
+	if err != nil {
+		t.Fatalf("GenerateAvailable failed: %v", err)
+	}
+	if id.Node() != testNodeID1 {
+		t.Errorf("Expected fallback to node %d, got node %d", testNodeID1, id.Node())
+	}
+	if got := second.RemainingSequence(); got != SeqMax {
+		t.Errorf("Second node RemainingSequence = %d, want %d", got, SeqMax)
+	}
+}
+
+func TestNode_RemainingSequence_GenerateAtomic(t *testing.T) {
+	fixed := time.Now()
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false),
+		WithNowFunc(func() time.Time { return fixed }))
+	other := newTestNode(t, testNodeID1, WithQuietMode(true), WithNowFunc(func() time.Time { return fixed }))
+
+	// The lock-free path takes the rest of the millisecond without touching the node's fields
+	node.GenerateSimple(testType1)
+	for i := int64(0); i < SeqMax; i++ {
+		if _, err := node.GenerateAtomic(testType1); err != nil {
+			t.Fatalf("GenerateAtomic %d failed: %v", i, err)
+		}
+	}
+	if got := node.RemainingSequence(); got != 0 {
+		t.Errorf("RemainingSequence after GenerateAtomic = %d, want 0", got)
+	}
+
+	cluster, err := NewCluster(node, other)
+	if err != nil {
+		t.Fatalf("NewCluster failed: %v", err)
+	}
+	if id, err := cluster.GenerateAvailable(testType1); err != nil || id.Node() != testNodeID1 {
+		t.Errorf("GenerateAvailable = %d, %v; want an ID from node %d", id, err, testNodeID1)
+	}
+}
+
+func TestNewCluster_Validation(t *testing.T) {
+	if _, err := NewCluster(); !errors.Is(err, ErrEmptyCluster) {
+		t.Errorf("Expected ErrEmptyCluster, got %v", err)
+	}
+	a := newTestNode(t, testNodeID0, WithQuietMode(true))
+	b := newTestNode(t, testNodeID0, WithQuietMode(true))
+	if _, err := NewCluster(a, b); !errors.Is(err, ErrDuplicateClusterID) {
+		t.Errorf("Expected ErrDuplicateClusterID, got %v", err)
+	}
+}
+
+func TestSkewBetween(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	a, err := newTestNode(t, testNodeID0, WithQuietMode(true)).GenerateWithTimestamp(testType1, base.Add(250*time.Millisecond))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+	b, err := newTestNode(t, testNodeID1, WithQuietMode(true)).GenerateWithTimestamp(testTypeMax, base)
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+
+	if got := SkewBetween(a, b); got != 250*time.Millisecond {
+		t.Errorf("SkewBetween(a, b) = %s, want 250ms", got)
+	}
+	if got := SkewBetween(b, a); got != -250*time.Millisecond {
+		t.Errorf("SkewBetween(b, a) = %s, want -250ms", got)
+	}
+	if got := SkewBetween(a, a); got != 0 {
+		t.Errorf("SkewBetween(a, a) = %s, want 0", got)
+	}
+}
@@ -0,0 +1,61 @@
+package arbiterid
+
+import (
+	"cmp"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrNotMonotonic is returned by VerifyMonotonic when a stream of IDs is not strictly increasing.
+var ErrNotMonotonic = errors.New("arbiterid: IDs not monotonically increasing")
+
+// Compare returns -1, 0, or +1 as id is less than, equal to, or greater than other,
+// for use with slices.SortFunc and similar.
+func (id ID) Compare(other ID) int {
+	return cmp.Compare(id, other)
+}
+
+// Less reports whether id sorts before other. Since the type occupies the most
+// significant bits, IDs of different types order by type first.
+func (id ID) Less(other ID) bool {
+	return id < other
+}
+
+// After reports whether id's timestamp is later than other's, ignoring type, node, and
+// sequence, for time-based filtering across types. IDs from the same millisecond are
+// never after one another.
+func (id ID) After(other ID) bool {
+	return int64(id)&TimestampMask > int64(other)&TimestampMask
+}
+
+// SortIDs sorts ids in ascending order, which for IDs of one type is generation order.
+func SortIDs(ids []ID) {
+	slices.Sort(ids)
+}
+
+// SecureEqual reports whether a and b are the same ID in constant time.
+//
+// The == operator may short-circuit and is not guaranteed to be constant time. Use
+// SecureEqual when an ID acts as a secret, such as an obfuscated ID handed out as a
+// capability token, so that comparison timing does not leak how much of a guess matched.
+func SecureEqual(a, b ID) bool {
+	var ab, bb [8]byte
+	binary.BigEndian.PutUint64(ab[:], uint64(a))
+	binary.BigEndian.PutUint64(bb[:], uint64(b))
+	return subtle.ConstantTimeCompare(ab[:], bb[:]) == 1
+}
+
+// VerifyMonotonic checks that ids is strictly increasing, as IDs from a single node are.
+// It returns the index of the first element that is not greater than its predecessor
+// along with an ErrNotMonotonic error, or -1 and nil if the whole slice is monotonic.
+func VerifyMonotonic(ids []ID) (int, error) {
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			return i, fmt.Errorf("%w: %d at index %d follows %d", ErrNotMonotonic, ids[i], i, ids[i-1])
+		}
+	}
+	return -1, nil
+}
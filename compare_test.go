@@ -0,0 +1,78 @@
+package arbiterid
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSecureEqual(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	a := node.GenerateSimple(testType1)
+	b := node.GenerateSimple(testType1)
+
+	ids := []ID{0, 1, a, b, a ^ 1, ID(math.MaxInt64)}
+	for _, x := range ids {
+		for _, y := range ids {
+			if got, want := SecureEqual(x, y), x == y; got != want {
+				t.Errorf("SecureEqual(%d, %d) = %t, want %t", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestVerifyMonotonic(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ids, err := node.GenerateBatch(testType1, 10)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+
+	if i, err := VerifyMonotonic(ids); i != -1 || err != nil {
+		t.Errorf("VerifyMonotonic(batch) = %d, %v; want -1, nil", i, err)
+	}
+	if i, err := VerifyMonotonic(nil); i != -1 || err != nil {
+		t.Errorf("VerifyMonotonic(nil) = %d, %v; want -1, nil", i, err)
+	}
+
+	ids[6], ids[7] = ids[7], ids[6]
+	if i, err := VerifyMonotonic(ids); i != 7 || !errors.Is(err, ErrNotMonotonic) {
+		t.Errorf("VerifyMonotonic(swapped) = %d, %v; want 7, ErrNotMonotonic", i, err)
+	}
+
+	dup := []ID{ids[0], ids[0]}
+	if i, err := VerifyMonotonic(dup); i != 1 || err == nil {
+		t.Errorf("VerifyMonotonic(duplicate) = %d, %v; want 1 and an error", i, err)
+	}
+}
+
+func TestID_CompareLessAfter(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithTypeAgnosticMonotonicity(true))
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	early, _ := node.GenerateWithTimestamp(testTypeMax, start)
+	late, _ := node.GenerateWithTimestamp(testType0, start.Add(time.Second))
+	sameMilli, _ := node.GenerateWithTimestamp(testType0, start.Add(time.Second))
+
+	if early.Compare(late) != 1 || late.Compare(early) != -1 || late.Compare(late) != 0 {
+		t.Errorf("Compare does not follow numeric order: %d, %d, %d", early.Compare(late), late.Compare(early), late.Compare(late))
+	}
+	// The higher type sorts first even though it is older
+	if !late.Less(early) || early.Less(late) || late.Less(late) {
+		t.Error("Less does not follow numeric order")
+	}
+	// After ignores the type and compares only timestamps
+	if !late.After(early) || early.After(late) {
+		t.Error("After should compare timestamps only")
+	}
+	if sameMilli.After(late) || late.After(sameMilli) {
+		t.Error("IDs from the same millisecond should not be after one another")
+	}
+
+	ids := []ID{sameMilli, early, late, 1}
+	SortIDs(ids)
+	if want := []ID{1, late, sameMilli, early}; !slices.Equal(ids, want) {
+		t.Errorf("SortIDs = %v, want %v", ids, want)
+	}
+}
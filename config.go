@@ -0,0 +1,190 @@
+package arbiterid
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Config holds a node's settings as a single struct, for loading from configuration files
+// or environment (e.g. with Viper or envconfig) and for dependency injection frameworks.
+// Start from DefaultConfig so that unset fields keep their defaults; the zero Config
+// disables strict monotonicity checks. Each field corresponds to the NodeOption of the
+// same name, and Options converts a Config to them.
+//
+// Config decodes from JSON using the same keys as Node.ConfigJSON, with durations given
+// as strings such as "250ms" (or as integer nanoseconds), so a node's exported
+// configuration can be used to build an identical node. Keys Config does not cover are ignored.
+type Config struct {
+	NodeID                   int           `json:"node_id"`
+	Epoch                    time.Time     `json:"epoch"`  // Zero keeps the package Epoch
+	Layout                   Layout        `json:"layout"` // Zero keeps DefaultLayout; TimestampBits is derived
+	StrictMonotonicity       bool          `json:"strict_monotonicity"`
+	TypeAgnosticMonotonicity bool          `json:"type_agnostic_monotonicity"`
+	QuietMode                bool          `json:"quiet_mode"`
+	SelfCheck                bool          `json:"self_check"`
+	TimestampReplayGuard     bool          `json:"timestamp_replay_guard"`
+	MaxFutureDrift           time.Duration `json:"max_future_drift"`
+	ClockGranularity         time.Duration `json:"clock_granularity"`
+	RecentHistory            int           `json:"recent_history"`
+	TypeVersionBits          uint8         `json:"type_version_bits"`
+	IdempotencyCache         int           `json:"idempotency_cache"`
+	RegionCode               uint8         `json:"region_code"`
+	HasRegion                bool          `json:"has_region"` // RegionCode applies only when set
+	AtomicLastID             bool          `json:"atomic_last_id"`
+	RandomSequenceStart      bool          `json:"random_sequence_start"`
+}
+
+// DefaultConfig returns the Config equivalent to NewNode(nodeID) with no options.
+func DefaultConfig(nodeID int) Config {
+	return Config{
+		NodeID:             nodeID,
+		Epoch:              time.UnixMilli(Epoch).UTC(),
+		Layout:             DefaultLayout,
+		StrictMonotonicity: true,
+		ClockGranularity:   time.Millisecond,
+	}
+}
+
+// Options returns the NodeOptions equivalent to c, so a Config can be combined with
+// options it cannot express: NewNode(c.NodeID, append(c.Options(), extra...)...).
+func (c Config) Options() []NodeOption {
+	opts := []NodeOption{
+		WithStrictMonotonicityCheck(c.StrictMonotonicity),
+		WithTypeAgnosticMonotonicity(c.TypeAgnosticMonotonicity),
+		WithQuietMode(c.QuietMode),
+		WithSelfCheck(c.SelfCheck),
+		WithTimestampReplayGuard(c.TimestampReplayGuard),
+		WithMaxFutureDrift(c.MaxFutureDrift),
+		WithClockGranularity(c.ClockGranularity),
+		WithRecentHistory(c.RecentHistory),
+		WithTypeVersioning(c.TypeVersionBits),
+		WithIdempotencyCache(c.IdempotencyCache),
+		WithAtomicLastID(c.AtomicLastID),
+		WithRandomSequenceStart(c.RandomSequenceStart),
+	}
+	if c.HasRegion {
+		opts = append(opts, WithRegionCode(c.RegionCode))
+	}
+	if !c.Epoch.IsZero() {
+		opts = append(opts, WithEpoch(c.Epoch))
+	}
+	if c.Layout != (Layout{}) {
+		opts = append(opts, WithBitLayout(c.Layout.TypeBits, c.Layout.NodeBits, c.Layout.SeqBits))
+	}
+	return opts
+}
+
+// NewNodeFromConfig creates a new Node from a Config rather than variadic options.
+func NewNodeFromConfig(cfg Config) (*Node, error) {
+	return NewNode(cfg.NodeID, cfg.Options()...)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings or nanoseconds.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plain Config
+	aux := struct {
+		*plain
+		MaxFutureDrift   jsonDuration `json:"max_future_drift"`
+		ClockGranularity jsonDuration `json:"clock_granularity"`
+	}{
+		plain:            (*plain)(c),
+		MaxFutureDrift:   jsonDuration(c.MaxFutureDrift),
+		ClockGranularity: jsonDuration(c.ClockGranularity),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.MaxFutureDrift = time.Duration(aux.MaxFutureDrift)
+	c.ClockGranularity = time.Duration(aux.ClockGranularity)
+	return nil
+}
+
+// jsonDuration decodes a time.Duration from a string such as "250ms" or integer nanoseconds.
+type jsonDuration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *jsonDuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var ns int64
+		if err := json.Unmarshal(b, &ns); err != nil {
+			return fmt.Errorf("arbiterid: invalid duration %s: expected a string or integer nanoseconds", b)
+		}
+		*d = jsonDuration(ns)
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("arbiterid: invalid duration %q: %w", s, err)
+	}
+	*d = jsonDuration(parsed)
+	return nil
+}
+
+// nodeConfigJSON is the serialized form of a node's configuration returned by ConfigJSON.
+type nodeConfigJSON struct {
+	NodeID                    int64  `json:"node_id"`
+	Epoch                     string `json:"epoch"`
+	EpochMillis               int64  `json:"epoch_millis"`
+	Layout                    Layout `json:"layout"`
+	StrictMonotonicity        bool   `json:"strict_monotonicity"`
+	TypeAgnosticMonotonicity  bool   `json:"type_agnostic_monotonicity"`
+	QuietMode                 bool   `json:"quiet_mode"`
+	SelfCheck                 bool   `json:"self_check"`
+	TimestampReplayGuard      bool   `json:"timestamp_replay_guard"`
+	MaxFutureDrift            string `json:"max_future_drift"`
+	ClockGranularity          string `json:"clock_granularity"`
+	RecentHistory             int    `json:"recent_history"`
+	TypeVersionBits           uint8  `json:"type_version_bits"`
+	IdempotencyCache          int    `json:"idempotency_cache"`
+	RegionCode                uint8  `json:"region_code"`
+	HasRegion                 bool   `json:"has_region"`
+	AtomicLastID              bool   `json:"atomic_last_id"`
+	RandomSequenceStart       bool   `json:"random_sequence_start"`
+	MaxRolloverWaitAttempts   int    `json:"max_rollover_wait_attempts"`
+	RolloverWaitCheckInterval string `json:"rollover_wait_check_interval"`
+}
+
+// ConfigJSON returns the node's configuration as JSON: node ID, epoch, bit layout,
+// monotonicity, logging, region and sequence options, and clock rollover parameters. It is intended for
+// ops tooling, such as a /config endpoint or detecting configuration drift across a fleet.
+// Mutable generation state (last ID, sequence) is not included.
+func (n *Node) ConfigJSON() ([]byte, error) {
+	n.mu.Lock()
+	cfg := n.configLocked()
+	n.mu.Unlock()
+
+	return json.Marshal(cfg)
+}
+
+// configLocked collects the node's configuration with the node's mutex held.
+func (n *Node) configLocked() nodeConfigJSON {
+	cfg := nodeConfigJSON{
+		NodeID:                    n.node,
+		Epoch:                     n.epoch.Format(time.RFC3339Nano),
+		EpochMillis:               n.epoch.UnixMilli(),
+		Layout:                    n.layout,
+		StrictMonotonicity:        n.strictMonotonicityChecks,
+		TypeAgnosticMonotonicity:  n.typeAgnosticMonotonicity,
+		QuietMode:                 n.quietMode,
+		SelfCheck:                 n.selfCheck,
+		TimestampReplayGuard:      n.timestampReplayGuard,
+		MaxFutureDrift:            n.maxFutureDrift.String(),
+		ClockGranularity:          (time.Duration(n.granularity) * time.Millisecond).String(),
+		TypeVersionBits:           n.typeVersionBits,
+		RegionCode:                n.region,
+		HasRegion:                 n.hasRegion,
+		AtomicLastID:              n.lockFreeLastID,
+		RandomSequenceStart:       n.randomSequenceStart,
+		MaxRolloverWaitAttempts:   maxRolloverWaitAttempts,
+		RolloverWaitCheckInterval: rolloverWaitCheckInterval.String(),
+	}
+	if n.history != nil {
+		cfg.RecentHistory = len(n.history.ids)
+	}
+	if n.idempotency != nil {
+		cfg.IdempotencyCache = n.idempotency.size
+	}
+	return cfg
+}
@@ -0,0 +1,175 @@
+package arbiterid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNode_ConfigJSON(t *testing.T) {
+	node := newTestNode(t, testNodeID1, WithQuietMode(true), WithStrictMonotonicityCheck(false),
+		WithMaxFutureDrift(250*time.Millisecond), WithRecentHistory(8), WithTimestampReplayGuard(true))
+
+	data, err := node.ConfigJSON()
+	if err != nil {
+		t.Fatalf("ConfigJSON failed: %v", err)
+	}
+
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("ConfigJSON produced invalid JSON %s: %v", data, err)
+	}
+
+	expect := map[string]interface{}{
+		"node_id":                      float64(testNodeID1),
+		"epoch":                        "2025-01-01T08:00:00Z",
+		"epoch_millis":                 float64(Epoch),
+		"strict_monotonicity":          false,
+		"quiet_mode":                   true,
+		"timestamp_replay_guard":       true,
+		"max_future_drift":             "250ms",
+		"clock_granularity":            "1ms",
+		"recent_history":               float64(8),
+		"max_rollover_wait_attempts":   float64(maxRolloverWaitAttempts),
+		"rollover_wait_check_interval": rolloverWaitCheckInterval.String(),
+	}
+	for key, want := range expect {
+		if got, ok := cfg[key]; !ok || got != want {
+			t.Errorf("ConfigJSON %q = %v, want %v", key, got, want)
+		}
+	}
+
+	layout, ok := cfg["layout"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("ConfigJSON layout missing or malformed: %s", data)
+	}
+	for key, want := range map[string]uint8{"type_bits": TypeBits, "timestamp_bits": TimestampBits, "node_bits": NodeBits, "seq_bits": SeqBits} {
+		if got := layout[key]; got != float64(want) {
+			t.Errorf("ConfigJSON layout %q = %v, want %d", key, got, want)
+		}
+	}
+}
+
+func TestNewNodeFromConfig(t *testing.T) {
+	data := []byte(`{
+		"node_id": 2,
+		"epoch": "2020-01-01T00:00:00Z",
+		"strict_monotonicity": false,
+		"quiet_mode": true,
+		"max_future_drift": "250ms",
+		"clock_granularity": 10000000,
+		"recent_history": 4
+	}`)
+
+	cfg := DefaultConfig(0)
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Unmarshal Config failed: %v", err)
+	}
+	want := Config{
+		NodeID:           2,
+		Epoch:            time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Layout:           DefaultLayout,
+		QuietMode:        true,
+		MaxFutureDrift:   250 * time.Millisecond,
+		ClockGranularity: 10 * time.Millisecond,
+		RecentHistory:    4,
+	}
+	if cfg != want {
+		t.Fatalf("Unmarshaled Config = %+v, want %+v", cfg, want)
+	}
+
+	node, err := NewNodeFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewNodeFromConfig failed: %v", err)
+	}
+	if node.node != 2 || !node.Epoch().Equal(want.Epoch) || node.strictMonotonicityChecks || !node.quietMode ||
+		node.maxFutureDrift != 250*time.Millisecond || node.granularity != 10 || len(node.history.ids) != 4 {
+		t.Errorf("Node does not reflect config %+v", cfg)
+	}
+
+	// A node's exported configuration rebuilds an equivalent node
+	exported, err := node.ConfigJSON()
+	if err != nil {
+		t.Fatalf("ConfigJSON failed: %v", err)
+	}
+	var roundTrip Config
+	if err := json.Unmarshal(exported, &roundTrip); err != nil {
+		t.Fatalf("Unmarshal ConfigJSON output failed: %v", err)
+	}
+	if roundTrip != cfg {
+		t.Errorf("Config from ConfigJSON = %+v, want %+v", roundTrip, cfg)
+	}
+
+	if _, err := NewNodeFromConfig(Config{NodeID: 9}); err == nil {
+		t.Error("Expected error for invalid node ID")
+	}
+	if err := json.Unmarshal([]byte(`{"max_future_drift": "soon"}`), &cfg); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+}
+
+// rebuildFromConfigJSON creates a node from node's ConfigJSON output and checks that the
+// new node exports the same configuration.
+func rebuildFromConfigJSON(t *testing.T, node *Node) (*Node, Config) {
+	t.Helper()
+	exported, err := node.ConfigJSON()
+	if err != nil {
+		t.Fatalf("ConfigJSON failed: %v", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(exported, &cfg); err != nil {
+		t.Fatalf("Unmarshal ConfigJSON output failed: %v", err)
+	}
+	rebuilt, err := NewNodeFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewNodeFromConfig failed: %v", err)
+	}
+	if got, _ := rebuilt.ConfigJSON(); string(got) != string(exported) {
+		t.Errorf("Rebuilt node config %s, want %s", got, exported)
+	}
+	return rebuilt, cfg
+}
+
+func TestConfig_RegionCode(t *testing.T) {
+	// Region 0 is distinct from no region, since it still narrows the type range
+	rebuilt, cfg := rebuildFromConfigJSON(t, newTestNode(t, testNodeID1, WithQuietMode(true), WithRegionCode(0)))
+	if !cfg.HasRegion || cfg.RegionCode != 0 || !rebuilt.hasRegion || rebuilt.region != 0 {
+		t.Errorf("Region code lost in config %+v", cfg)
+	}
+
+	regional, err := NewNodeFromConfig(Config{NodeID: testNodeID1, QuietMode: true, RegionCode: 5, HasRegion: true})
+	if err != nil {
+		t.Fatalf("NewNodeFromConfig with region failed: %v", err)
+	}
+	if id := regional.GenerateSimple(testType1); id.Region() != 5 {
+		t.Errorf("ID from regional node has region %d, want 5", id.Region())
+	}
+}
+
+func TestConfig_AtomicLastID(t *testing.T) {
+	rebuilt, cfg := rebuildFromConfigJSON(t, newTestNode(t, testNodeID1, WithQuietMode(true), WithAtomicLastID(true)))
+	if !cfg.AtomicLastID || !rebuilt.lockFreeLastID {
+		t.Errorf("WithAtomicLastID lost in config %+v", cfg)
+	}
+}
+
+func TestConfig_RandomSequenceStart(t *testing.T) {
+	rebuilt, cfg := rebuildFromConfigJSON(t, newTestNode(t, testNodeID1, WithQuietMode(true), WithRandomSequenceStart(true)))
+	if !cfg.RandomSequenceStart || !rebuilt.randomSequenceStart {
+		t.Errorf("WithRandomSequenceStart lost in config %+v", cfg)
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	node, err := NewNodeFromConfig(DefaultConfig(testNodeID1))
+	if err != nil {
+		t.Fatalf("NewNodeFromConfig failed: %v", err)
+	}
+	defaults := newTestNode(t, testNodeID1)
+
+	got, _ := node.ConfigJSON()
+	want, _ := defaults.ConfigJSON()
+	if string(got) != string(want) {
+		t.Errorf("DefaultConfig node config %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package arbiterid
+
+import (
+	"fmt"
+	"math"
+)
+
+// encodeCrockfordMap is Crockford's Base32 alphabet, as used by ULID
+const encodeCrockfordMap = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordLen is the fixed length of ID.Base32Crockford: 13 digits hold 65 bits
+const crockfordLen = 13
+
+var decodeCrockfordMap [256]byte
+
+func init() {
+	for i := range decodeCrockfordMap {
+		decodeCrockfordMap[i] = 0xFF
+	}
+	for i := 0; i < len(encodeCrockfordMap); i++ {
+		c := encodeCrockfordMap[i]
+		decodeCrockfordMap[c] = byte(i)
+		decodeCrockfordMap[c|0x20] = byte(i) // Lower case; a no-op for digits
+	}
+	// Crockford's ambiguity handling: I and L read as 1, O as 0
+	for _, c := range []byte("IiLl") {
+		decodeCrockfordMap[c] = 1
+	}
+	decodeCrockfordMap['O'], decodeCrockfordMap['o'] = 0, 0
+}
+
+// Base32Crockford returns the ID in Crockford's Base32, the encoding used by ULID, as 13
+// upper-case characters. Being fixed-width, the strings sort lexicographically in ID
+// order. This is a different alphabet from Base32, which is z-base-32.
+func (id ID) Base32Crockford() string {
+	var buf [crockfordLen]byte
+	n := uint64(id)
+	for i := crockfordLen - 1; i >= 0; i-- {
+		buf[i] = encodeCrockfordMap[n%32]
+		n /= 32
+	}
+	return string(buf[:])
+}
+
+// ParseBase32Crockford converts a Crockford Base32 string into an ID. Decoding is
+// case-insensitive, ignores hyphens, and reads I and L as 1 and O as 0. Leading zeros
+// may be omitted.
+func ParseBase32Crockford(s string) (ID, error) {
+	var (
+		val    uint64
+		digits int
+	)
+	for i := 0; i < len(s); i++ {
+		char := s[i]
+		if char == '-' {
+			continue
+		}
+		decodedByte := decodeCrockfordMap[char]
+		if decodedByte == 0xFF {
+			return 0, fmt.Errorf("%w: invalid Crockford char '%c' in '%s'", ErrInvalidBase32, char, s)
+		}
+		if digits++; digits > crockfordLen {
+			return 0, fmt.Errorf("%w: input string '%s' too long (max %d digits)", ErrInvalidBase32, s, crockfordLen)
+		}
+		if val > (math.MaxUint64-uint64(decodedByte))/32 {
+			return 0, fmt.Errorf("%w: value '%s' overflows uint64", ErrInvalidBase32, s)
+		}
+		val = val*32 + uint64(decodedByte)
+	}
+	if digits == 0 {
+		return 0, fmt.Errorf("%w: input string '%s' has no digits", ErrInvalidBase32, s)
+	}
+	if val > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: value '%s' overflows positive int64", ErrInvalidBase32, s)
+	}
+	return ID(val), nil
+}
@@ -0,0 +1,60 @@
+package arbiterid
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestID_Base32Crockford(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	for _, id := range []ID{0, 1, idForEncodingTests, ID(math.MaxInt64), node.GenerateSimple(testType1)} {
+		s := id.Base32Crockford()
+		if len(s) != 13 || strings.ToUpper(s) != s {
+			t.Errorf("Base32Crockford(%d) = %q, want 13 upper-case characters", id, s)
+		}
+		for _, in := range []string{s, strings.ToLower(s), s[:4] + "-" + s[4:8] + "-" + s[8:]} {
+			if parsed, err := ParseBase32Crockford(in); err != nil || parsed != id {
+				t.Errorf("ParseBase32Crockford(%q) = %d, %v; want %d", in, parsed, err, id)
+			}
+		}
+	}
+	if got := ID(31).Base32Crockford(); got != "000000000000Z" {
+		t.Errorf("Base32Crockford(31) = %q", got)
+	}
+	// The existing z-base-32 encoding is unchanged
+	if got := ID(31).Base32(); got != "9" {
+		t.Errorf("Base32(31) = %q, want z-base-32 %q", got, "9")
+	}
+}
+
+func TestParseBase32Crockford_Ambiguity(t *testing.T) {
+	for in, want := range map[string]ID{"1": 1, "I": 1, "i": 1, "L": 1, "l": 1, "O": 0, "o": 0, "1O": 32, "z": 31} {
+		if got, err := ParseBase32Crockford(in); err != nil || got != want {
+			t.Errorf("ParseBase32Crockford(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+
+	for _, bad := range []string{"", "-", "U", "AB*C", "00000000000000", "8000000000000", "G000000000001", "H000000000000", "ZZZZZZZZZZZZZ"} {
+		if _, err := ParseBase32Crockford(bad); !errors.Is(err, ErrInvalidBase32) {
+			t.Errorf("ParseBase32Crockford(%q): expected ErrInvalidBase32, got %v", bad, err)
+		}
+	}
+}
+
+func TestID_Base32Crockford_Sorts(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ids, err := node.GenerateBatch(testType1, 2000)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	encoded := make([]string, len(ids))
+	for i, id := range ids {
+		encoded[i] = id.Base32Crockford()
+	}
+	if !sort.StringsAreSorted(encoded) {
+		t.Error("Crockford strings do not sort in ID order")
+	}
+}
@@ -0,0 +1,66 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNode_Cursor(t *testing.T) {
+	key := []byte("pagination-secret")
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithCursorKey(key))
+
+	for _, id := range []ID{0, node.GenerateSimple(testType1), ID(1<<63 - 1)} {
+		token, err := node.Cursor(id)
+		if err != nil {
+			t.Fatalf("Cursor(%d) failed: %v", id, err)
+		}
+		if got, err := node.ParseCursor(token); err != nil || got != id {
+			t.Errorf("ParseCursor(%q) = %d, %v; want %d", token, got, err, id)
+		}
+	}
+
+	id := node.GenerateSimple(testType1)
+	token, _ := node.Cursor(id)
+
+	// Another node sharing the key accepts the cursor
+	peer := newTestNode(t, testNodeID1, WithQuietMode(true), WithCursorKey(key))
+	if got, err := peer.ParseCursor(token); err != nil || got != id {
+		t.Errorf("Peer ParseCursor = %d, %v; want %d", got, err, id)
+	}
+
+	t.Run("Tampered", func(t *testing.T) {
+		// Flip one character of the encoded ID
+		b := []byte(token)
+		b[3] ^= 1
+		if _, err := node.ParseCursor(string(b)); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for a tampered cursor, got %v", err)
+		}
+
+		// A cursor crafted for a different ID with a guessed signature
+		forged, _ := node.Cursor(id + 1)
+		if _, err := node.ParseCursor(token[:11] + forged[11:]); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for a spliced cursor, got %v", err)
+		}
+
+		other := newTestNode(t, testNodeID0, WithQuietMode(true), WithCursorKey([]byte("other-secret")))
+		if _, err := other.ParseCursor(token); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor under a different key, got %v", err)
+		}
+
+		for _, bad := range []string{"", "not a cursor!", id.Base64()} {
+			if _, err := node.ParseCursor(bad); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("ParseCursor(%q): expected ErrInvalidCursor, got %v", bad, err)
+			}
+		}
+	})
+
+	t.Run("NoKey", func(t *testing.T) {
+		plain := newTestNode(t, testNodeID0, WithQuietMode(true), WithCursorKey(nil))
+		if _, err := plain.Cursor(id); !errors.Is(err, ErrNoCursorKey) {
+			t.Errorf("Expected ErrNoCursorKey, got %v", err)
+		}
+		if _, err := plain.ParseCursor(token); !errors.Is(err, ErrNoCursorKey) {
+			t.Errorf("Expected ErrNoCursorKey, got %v", err)
+		}
+	})
+}
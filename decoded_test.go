@@ -0,0 +1,59 @@
+package arbiterid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCachedID(t *testing.T) {
+	node := newTestNode(t, testNodeID1, WithQuietMode(true))
+	id, err := node.GenerateWithTimestamp(42, time.Date(2025, 6, 1, 12, 0, 0, 5e6, time.UTC))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+
+	c := NewCachedID(id)
+	for i := 0; i < 2; i++ {
+		if c.ID() != id || c.Type() != id.Type() || c.Time() != id.Time() || !c.TimeTime().Equal(id.TimeTime()) ||
+			c.Node() != id.Node() || c.Seq() != id.Seq() {
+			t.Errorf("Access %d: CachedID fields %+v do not match ID %d", i, c.Decoded(), id)
+		}
+	}
+
+	want := Decoded{Type: 42, Time: id.Time(), TimeTime: id.TimeTime(), Node: testNodeID1, Seq: 0}
+	if got := id.Decode(); got != want {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+
+	var zero CachedID
+	if zero.ID() != 0 || zero.Decoded() != ID(0).Decode() {
+		t.Errorf("Zero CachedID should decode ID 0, got %+v", zero.Decoded())
+	}
+}
+
+func BenchmarkID_RepeatedFieldAccess(b *testing.B) {
+	id := ID(1234567890123456789)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 4; j++ {
+			_ = id.Type()
+			_ = id.TimeTime()
+			_ = id.Node()
+			_ = id.Seq()
+		}
+	}
+}
+
+func BenchmarkCachedID_RepeatedFieldAccess(b *testing.B) {
+	id := ID(1234567890123456789)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := CachedID{id: id}
+		for j := 0; j < 4; j++ {
+			_ = c.Type()
+			_ = c.TimeTime()
+			_ = c.Node()
+			_ = c.Seq()
+		}
+	}
+}
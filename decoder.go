@@ -0,0 +1,72 @@
+package arbiterid
+
+import "time"
+
+// Decoder reads the components of IDs for a given epoch and bit layout. It has no way to
+// generate IDs, so a read-only service holding only a Decoder cannot mint them.
+// String encodings do not depend on the layout; use the package Parse functions for them.
+type Decoder struct {
+	epochMillis int64
+	layout      Layout
+}
+
+// NewDecoderOnly returns a Decoder for IDs whose timestamps count milliseconds from
+// epochMillis (Unix milliseconds, e.g. Epoch) and whose sections follow layout.
+// It panics if layout is invalid, since that is a programming error.
+func NewDecoderOnly(epochMillis int64, layout Layout) *Decoder {
+	if err := layout.Validate(); err != nil {
+		panic(err)
+	}
+	return &Decoder{epochMillis: epochMillis, layout: layout}
+}
+
+// Layout returns the decoder's bit layout
+func (d *Decoder) Layout() Layout {
+	return d.layout
+}
+
+// Epoch returns the decoder's epoch
+func (d *Decoder) Epoch() time.Time {
+	return time.UnixMilli(d.epochMillis).UTC()
+}
+
+// Type returns the type component of id
+func (d *Decoder) Type(id ID) int64 {
+	return int64(id) >> d.layout.typeShift() & d.layout.typeMax()
+}
+
+// Time returns the timestamp of id in Unix milliseconds
+func (d *Decoder) Time(id ID) int64 {
+	return int64(id)>>d.layout.timeShift()&d.layout.timeMax() + d.epochMillis
+}
+
+// TimeTime returns the timestamp of id as a time.Time in UTC
+func (d *Decoder) TimeTime(id ID) time.Time {
+	return time.UnixMilli(d.Time(id)).UTC()
+}
+
+// Node returns the node component of id
+func (d *Decoder) Node(id ID) int64 {
+	return int64(id) >> d.layout.nodeShift() & d.layout.nodeMax()
+}
+
+// Seq returns the sequence component of id
+func (d *Decoder) Seq(id ID) int64 {
+	return int64(id) & d.layout.seqMax()
+}
+
+// Components extracts all components of id. The timestamp is in Unix milliseconds.
+func (d *Decoder) Components(id ID) (idType IDType, timestampMillisUnix int64, node int64, seq int64) {
+	return IDType(d.Type(id)), d.Time(id), d.Node(id), d.Seq(id)
+}
+
+// Decode extracts all components of id into a Decoded.
+func (d *Decoder) Decode(id ID) Decoded {
+	return Decoded{
+		Type:     IDType(d.Type(id)),
+		Time:     d.Time(id),
+		TimeTime: d.TimeTime(id),
+		Node:     d.Node(id),
+		Seq:      d.Seq(id),
+	}
+}
@@ -0,0 +1,74 @@
+package arbiterid
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecoder_DefaultLayout(t *testing.T) {
+	node := newTestNode(t, testNodeID1, WithQuietMode(true))
+	id, err := node.GenerateWithTimestamp(42, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+
+	d := NewDecoderOnly(Epoch, DefaultLayout)
+	if got, want := d.Decode(id), id.Decode(); got != want {
+		t.Errorf("Decoder.Decode = %+v, want %+v", got, want)
+	}
+	typ, ts, nodeID, seq := d.Components(id)
+	wantType, wantTS, wantNode, wantSeq := id.Components()
+	if typ != wantType || ts != wantTS || nodeID != wantNode || seq != wantSeq {
+		t.Errorf("Decoder.Components = %d %d %d %d, want %d %d %d %d", typ, ts, nodeID, seq, wantType, wantTS, wantNode, wantSeq)
+	}
+	if !d.Epoch().Equal(time.UnixMilli(Epoch)) || d.Layout() != DefaultLayout {
+		t.Errorf("Decoder reports epoch %s and layout %+v", d.Epoch(), d.Layout())
+	}
+}
+
+func TestDecoder_CustomLayout(t *testing.T) {
+	layout := Layout{TypeBits: 4, TimestampBits: 42, NodeBits: 5, SeqBits: 12}
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 8e6, time.UTC)
+
+	// type 9, node 17, seq 3000
+	id := ID(9<<59 | (ts.UnixMilli()-epoch)<<17 | 17<<12 | 3000)
+
+	d := NewDecoderOnly(epoch, layout)
+	if d.Type(id) != 9 || !d.TimeTime(id).Equal(ts) || d.Node(id) != 17 || d.Seq(id) != 3000 {
+		t.Errorf("Decoded %+v, want type 9, time %s, node 17, seq 3000", d.Decode(id), ts)
+	}
+}
+
+func TestDecoder_HasNoGenerateMethods(t *testing.T) {
+	typ := reflect.TypeOf(&Decoder{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		if name := typ.Method(i).Name; strings.HasPrefix(name, "Generate") {
+			t.Errorf("Decoder exposes generation method %s", name)
+		}
+	}
+}
+
+func TestLayout_Validate(t *testing.T) {
+	if err := DefaultLayout.Validate(); err != nil {
+		t.Errorf("DefaultLayout invalid: %v", err)
+	}
+	for _, l := range []Layout{
+		{TypeBits: 10, TimestampBits: 41, NodeBits: 2, SeqBits: 11},
+		{TypeBits: 0, TimestampBits: 51, NodeBits: 2, SeqBits: 10},
+	} {
+		if err := l.Validate(); !errors.Is(err, ErrInvalidLayout) {
+			t.Errorf("Layout %+v: expected ErrInvalidLayout, got %v", l, err)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewDecoderOnly should panic on an invalid layout")
+		}
+	}()
+	NewDecoderOnly(Epoch, Layout{})
+}
@@ -0,0 +1,53 @@
+package arbiterid
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Describe returns a human-readable, multi-line report of the node for support bundles
+// and bug reports: node ID, epoch, bit layout, current time and sequence, the last ID in
+// decimal, Base58 and ISO time, generation counters, and the configured options. The
+// format is meant for people and may change; use ConfigJSON and Snapshot for tooling.
+func (n *Node) Describe() string {
+	n.mu.Lock()
+	cfg := n.configLocked()
+	snap := n.snapshotLocked()
+	n.mu.Unlock()
+
+	d := n.Decoder()
+	l := cfg.Layout
+	var b strings.Builder
+	fmt.Fprintf(&b, "ArbiterID node %d\n", cfg.NodeID)
+	fmt.Fprintf(&b, "  Epoch:            %s (%d)\n", cfg.Epoch, cfg.EpochMillis)
+	fmt.Fprintf(&b, "  Layout:           type=%d timestamp=%d node=%d seq=%d bits\n",
+		l.TypeBits, l.TimestampBits, l.NodeBits, l.SeqBits)
+	fmt.Fprintf(&b, "  Time:             %dms after epoch (%s)\n",
+		snap.Time, time.UnixMilli(cfg.EpochMillis+snap.Time).UTC().Format(timeISOMillisLayout))
+	fmt.Fprintf(&b, "  Seq:              %d of %d\n", snap.Seq, l.seqMax())
+	if snap.LastID == 0 {
+		fmt.Fprintf(&b, "  Last ID:          none\n")
+	} else {
+		fmt.Fprintf(&b, "  Last ID:          %d / %s / %s\n",
+			snap.LastID, snap.LastID.Base58(), d.TimeTime(snap.LastID).Format(timeISOMillisLayout))
+	}
+	fmt.Fprintf(&b, "  Generated:        %d\n", n.GeneratedCount())
+	fmt.Fprintf(&b, "  Clock warnings:   %d\n", snap.ClockWarningCount)
+	fmt.Fprintf(&b, "  Options:\n")
+	fmt.Fprintf(&b, "    strict_monotonicity=%t type_agnostic_monotonicity=%t\n",
+		cfg.StrictMonotonicity, cfg.TypeAgnosticMonotonicity)
+	fmt.Fprintf(&b, "    quiet_mode=%t self_check=%t timestamp_replay_guard=%t\n",
+		cfg.QuietMode, cfg.SelfCheck, cfg.TimestampReplayGuard)
+	fmt.Fprintf(&b, "    max_future_drift=%s clock_granularity=%s\n", cfg.MaxFutureDrift, cfg.ClockGranularity)
+	fmt.Fprintf(&b, "    recent_history=%d type_version_bits=%d idempotency_cache=%d\n",
+		cfg.RecentHistory, cfg.TypeVersionBits, cfg.IdempotencyCache)
+	region := "none"
+	if cfg.HasRegion {
+		region = fmt.Sprint(cfg.RegionCode)
+	}
+	fmt.Fprintf(&b, "    region_code=%s atomic_last_id=%t random_sequence_start=%t\n",
+		region, cfg.AtomicLastID, cfg.RandomSequenceStart)
+	fmt.Fprintf(&b, "    rollover_wait=%d x %s\n", cfg.MaxRolloverWaitAttempts, cfg.RolloverWaitCheckInterval)
+	return b.String()
+}
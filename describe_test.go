@@ -0,0 +1,39 @@
+package arbiterid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNode_Describe(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	node := newTestNode(t, testNodeID1, WithQuietMode(true), WithManualClock(NewManualClock(start)))
+
+	report := node.Describe()
+	for _, want := range []string{"ArbiterID node 1\n", "Last ID:          none", "type=10 timestamp=41 node=2 seq=10"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report for a fresh node is missing %q:\n%s", want, report)
+		}
+	}
+
+	id := node.GenerateSimple(testType1)
+	report = node.Describe()
+	for _, want := range []string{
+		"ArbiterID node 1\n",
+		id.String(),
+		id.Base58(),
+		"2025-06-01T12:00:00.000Z",
+		"Generated:        1\n",
+		"strict_monotonicity=true",
+		"quiet_mode=true",
+		"region_code=none atomic_last_id=false random_sequence_start=false",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report is missing %q:\n%s", want, report)
+		}
+	}
+	if lines := strings.Count(report, "\n"); lines < 10 {
+		t.Errorf("Expected a multi-line report, got %d lines:\n%s", lines, report)
+	}
+}
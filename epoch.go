@@ -0,0 +1,65 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Epoch errors
+var (
+	ErrInvalidEpoch    = errors.New("arbiterid: epoch must not be in the future")
+	ErrInferEpochInput = errors.New("arbiterid: InferEpoch needs one known time per ID")
+)
+
+// WithEpoch sets the node's epoch, the time its timestamps count milliseconds from,
+// truncated to whole milliseconds. Default is the package Epoch. Use it to keep
+// generating IDs compatible with an existing Snowflake-style deployment.
+//
+// IDs minted under a different epoch are not comparable with IDs minted under the default
+// one: the same timestamp encodes to a different value. The ID methods Time, TimeTime, and
+// Components always assume the package Epoch; decode this node's IDs with Node.Decoder.
+// NewNode returns ErrInvalidEpoch if epoch is after the current time.
+func WithEpoch(epoch time.Time) NodeOption {
+	return func(n *Node) {
+		n.epoch = epoch.UTC().Truncate(time.Millisecond)
+	}
+}
+
+// validateEpoch rejects an epoch in the future, which would make current timestamps negative.
+func (n *Node) validateEpoch() error {
+	if now := time.Now(); n.epoch.After(now) {
+		return fmt.Errorf("%w: %s is after current time %s", ErrInvalidEpoch,
+			n.epoch.Format(time.RFC3339Nano), now.UTC().Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// Epoch returns the time the node's timestamps count from.
+func (n *Node) Epoch() time.Time {
+	return n.epoch
+}
+
+// Decoder returns a Decoder bound to the node's epoch and layout, which decodes the
+// node's IDs correctly even when WithEpoch is set.
+func (n *Node) Decoder() *Decoder {
+	return NewDecoderOnly(n.epoch.UnixMilli(), n.layout)
+}
+
+// InferEpoch estimates the epoch, in Unix milliseconds, of IDs from a system with an
+// unknown epoch, given the real creation time of each ID. Each pair yields the epoch that
+// aligns the ID's embedded timestamp with its known time; the median of these is returned,
+// so a few inaccurate known times do not skew the result. The IDs must use the default
+// bit layout.
+func InferEpoch(ids []ID, knownTimes []time.Time) (int64, error) {
+	if len(ids) == 0 || len(ids) != len(knownTimes) {
+		return 0, fmt.Errorf("%w: got %d IDs and %d times", ErrInferEpochInput, len(ids), len(knownTimes))
+	}
+	estimates := make([]int64, len(ids))
+	for i, id := range ids {
+		estimates[i] = knownTimes[i].UnixMilli() - (int64(id)&TimestampMask)>>TimeShift
+	}
+	slices.Sort(estimates)
+	return estimates[len(estimates)/2], nil
+}
@@ -0,0 +1,87 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithEpoch(t *testing.T) {
+	legacyEpoch := time.Date(2010, 11, 4, 1, 42, 54, 657e6, time.UTC) // Twitter's Snowflake epoch
+	node := newTestNode(t, testNodeID1, WithQuietMode(true), WithEpoch(legacyEpoch))
+	if !node.Epoch().Equal(legacyEpoch) {
+		t.Errorf("Epoch() = %s, want %s", node.Epoch(), legacyEpoch)
+	}
+
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	id, err := node.GenerateWithTimestamp(testType1, ts)
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+	if millis := (int64(id) & TimestampMask) >> TimeShift; millis != ts.Sub(legacyEpoch).Milliseconds() {
+		t.Errorf("Encoded timestamp %dms, want %dms since the custom epoch", millis, ts.Sub(legacyEpoch).Milliseconds())
+	}
+
+	d := node.Decoder()
+	if got := d.TimeTime(id); !got.Equal(ts) {
+		t.Errorf("Decoder.TimeTime = %s, want %s", got, ts)
+	}
+	if d.Type(id) != int64(testType1) || d.Node(id) != testNodeID1 {
+		t.Errorf("Decoder components %+v do not match", d.Decode(id))
+	}
+
+	// The package-level decoding assumes the default epoch, so it is off by the difference
+	if id.TimeTime().Equal(ts) {
+		t.Error("ID.TimeTime unexpectedly matched under a custom epoch")
+	}
+
+	live, err := node.Generate(testType1)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if drift := time.Since(d.TimeTime(live)); drift < 0 || drift > time.Second {
+		t.Errorf("Generated ID decodes to %s, not the current time", d.TimeTime(live))
+	}
+}
+
+func TestWithEpoch_FutureRejected(t *testing.T) {
+	_, err := NewNode(0, WithQuietMode(true), WithEpoch(time.Now().Add(time.Hour)))
+	if !errors.Is(err, ErrInvalidEpoch) {
+		t.Errorf("Expected ErrInvalidEpoch, got %v", err)
+	}
+}
+
+func TestInferEpoch(t *testing.T) {
+	legacyEpoch := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithEpoch(legacyEpoch))
+
+	base := time.Now().Add(-time.Hour)
+	var ids []ID
+	var known []time.Time
+	for i := 0; i < 9; i++ {
+		created := base.Add(time.Duration(i) * time.Minute)
+		id, err := node.GenerateWithTimestamp(testType1, created)
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		ids = append(ids, id)
+		known = append(known, created)
+	}
+	// An outlier from a badly skewed recording clock does not move the median
+	known[4] = known[4].Add(time.Hour)
+
+	epoch, err := InferEpoch(ids, known)
+	if err != nil {
+		t.Fatalf("InferEpoch failed: %v", err)
+	}
+	if epoch != legacyEpoch.UnixMilli() {
+		t.Errorf("InferEpoch = %d (%s), want %d", epoch, time.UnixMilli(epoch).UTC(), legacyEpoch.UnixMilli())
+	}
+
+	if _, err := InferEpoch(ids, known[:3]); !errors.Is(err, ErrInferEpochInput) {
+		t.Errorf("Expected ErrInferEpochInput for mismatched lengths, got %v", err)
+	}
+	if _, err := InferEpoch(nil, nil); !errors.Is(err, ErrInferEpochInput) {
+		t.Errorf("Expected ErrInferEpochInput for empty input, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package arbiterid
+
+import "errors"
+
+// ErrorKind is a broad category of generation failure, letting callers handle errors
+// without matching each sentinel individually.
+type ErrorKind int
+
+// Error categories returned by ClassifyError
+const (
+	KindUnknown           ErrorKind = iota // Nil, or not a recognized generation error
+	KindInvalidType                        // ErrInvalIDType, ErrUnregisteredType
+	KindClockStuck                         // ErrClockNotAdvancing, ErrClockBehindState
+	KindSequenceExhausted                  // ErrSequenceExhausted
+	KindOverflow                           // ErrTimestampOverflow
+	KindMonotonicity                       // ErrMonotonicityViolation
+)
+
+// String returns a short lowercase name for the kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case KindInvalidType:
+		return "invalid type"
+	case KindClockStuck:
+		return "clock stuck"
+	case KindSequenceExhausted:
+		return "sequence exhausted"
+	case KindOverflow:
+		return "overflow"
+	case KindMonotonicity:
+		return "monotonicity"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassifyError maps an error returned by this package, possibly wrapped further, to its
+// ErrorKind using errors.Is. Sequence exhaustion is reported as KindSequenceExhausted
+// even though it also matches ErrClockNotAdvancing. Other errors yield KindUnknown.
+func ClassifyError(err error) ErrorKind {
+	switch {
+	case err == nil:
+		return KindUnknown
+	case errors.Is(err, ErrInvalIDType), errors.Is(err, ErrUnregisteredType):
+		return KindInvalidType
+	case errors.Is(err, ErrSequenceExhausted):
+		return KindSequenceExhausted
+	case errors.Is(err, ErrClockNotAdvancing), errors.Is(err, ErrClockBehindState):
+		return KindClockStuck
+	case errors.Is(err, ErrTimestampOverflow):
+		return KindOverflow
+	case errors.Is(err, ErrMonotonicityViolation):
+		return KindMonotonicity
+	default:
+		return KindUnknown
+	}
+}
@@ -0,0 +1,59 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{ErrInvalIDType, KindInvalidType},
+		{ErrUnregisteredType, KindInvalidType},
+		{ErrClockNotAdvancing, KindClockStuck},
+		{ErrSequenceExhausted, KindSequenceExhausted},
+		{ErrTimestampOverflow, KindOverflow},
+		{ErrMonotonicityViolation, KindMonotonicity},
+		{fmt.Errorf("wrapped: %w", ErrMonotonicityViolation), KindMonotonicity},
+		{ErrInvalidNodeID, KindUnknown},
+		{errors.New("other"), KindUnknown},
+		{nil, KindUnknown},
+	}
+	for _, tt := range tests {
+		if got := ClassifyError(tt.err); got != tt.want {
+			t.Errorf("ClassifyError(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyError_GeneratedErrors(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	_, err := node.Generate(IDType(TypeMax + 1))
+	if got := ClassifyError(err); got != KindInvalidType {
+		t.Errorf("Invalid type error classified as %s", got)
+	}
+
+	ts := time.Now()
+	for i := int64(0); i <= SeqMax; i++ {
+		if _, err := node.GenerateWithTimestamp(testType1, ts); err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+	}
+	_, err = node.GenerateWithTimestamp(testType1, ts)
+	if got := ClassifyError(err); got != KindSequenceExhausted {
+		t.Errorf("Sequence exhaustion classified as %s: %v", got, err)
+	}
+	if !errors.Is(err, ErrClockNotAdvancing) {
+		t.Errorf("Sequence exhaustion should still match ErrClockNotAdvancing: %v", err)
+	}
+
+	_, err = node.GenerateWithTimestamp(testType1, node.epoch.Add(time.Duration(TimestampMax+1)*time.Millisecond))
+	if got := ClassifyError(err); got != KindOverflow {
+		t.Errorf("Timestamp overflow classified as %s: %v", got, err)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/githonllc/arbiterid"
+	"github.com/githonllc/arbiterid/examples/service/idservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxStreamCount caps GenerateStream; streaming makes larger batches than POST /generate reasonable
+const maxStreamCount = 10000
+
+// idServer implements idservice.IDServiceServer on top of the server's node
+type idServer struct {
+	idservice.UnimplementedIDServiceServer
+	server *Server
+}
+
+// newGRPCServer creates a gRPC server with the ID service registered
+func newGRPCServer(server *Server) *grpc.Server {
+	gs := grpc.NewServer()
+	idservice.RegisterIDServiceServer(gs, &idServer{server: server})
+	return gs
+}
+
+// Generate handles IDService.Generate
+func (s *idServer) Generate(ctx context.Context, req *idservice.GenerateRequest) (*idservice.GenerateResponse, error) {
+	if req.GetType() > uint32(arbiterid.TypeMax) {
+		return nil, status.Errorf(codes.InvalidArgument, "type must be between 0 and %d", arbiterid.TypeMax)
+	}
+	id, err := s.server.generate(ctx, arbiterid.IDType(req.GetType()))
+	if err != nil {
+		return nil, generateStatus(err)
+	}
+	return newIDMessage(id), nil
+}
+
+// GenerateStream handles IDService.GenerateStream, stopping early if the client goes away
+func (s *idServer) GenerateStream(req *idservice.GenerateStreamRequest, stream grpc.ServerStreamingServer[idservice.GenerateResponse]) error {
+	if req.GetType() > uint32(arbiterid.TypeMax) {
+		return status.Errorf(codes.InvalidArgument, "type must be between 0 and %d", arbiterid.TypeMax)
+	}
+	if req.GetCount() < 1 || req.GetCount() > maxStreamCount {
+		return status.Errorf(codes.InvalidArgument, "count must be between 1 and %d", maxStreamCount)
+	}
+
+	ctx := stream.Context()
+	for i := uint32(0); i < req.GetCount(); i++ {
+		id, err := s.server.generate(ctx, arbiterid.IDType(req.GetType()))
+		if err != nil {
+			return generateStatus(err)
+		}
+		if err := stream.Send(newIDMessage(id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newIDMessage builds the gRPC representation of an ID
+func newIDMessage(id arbiterid.ID) *idservice.GenerateResponse {
+	idType, _, node, seq := id.Components()
+	return &idservice.GenerateResponse{
+		Id:       id.Int64(),
+		IdBytes:  id.ProtoBytes(),
+		Base58:   id.Base58(),
+		Type:     uint32(idType),
+		Time:     id.TimeISO(),
+		Node:     node,
+		Sequence: seq,
+	}
+}
+
+// generateStatus maps a generation error to a gRPC status
+func generateStatus(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	if errors.Is(err, errShuttingDown) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	switch arbiterid.ClassifyError(err) {
+	case arbiterid.KindInvalidType:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case arbiterid.KindClockStuck, arbiterid.KindSequenceExhausted:
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("failed to generate ID: %v", err))
+	}
+}
+
+// startGRPC serves the ID service on port in the background
+func (s *Server) startGRPC(port string) error {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC on port %s: %w", port, err)
+	}
+	gs := newGRPCServer(s)
+	s.mu.Lock()
+	s.grpcServer = gs
+	s.mu.Unlock()
+	go func() {
+		if err := gs.Serve(lis); err != nil {
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+	log.Printf("Starting ArbiterID gRPC service on port %s", port)
+	return nil
+}
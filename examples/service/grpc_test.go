@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/githonllc/arbiterid"
+	"github.com/githonllc/arbiterid/examples/service/idservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves the ID service for a node with the given ID over an in-memory
+// connection and returns a client for it.
+func newTestClient(t *testing.T, nodeID int) idservice.IDServiceClient {
+	t.Helper()
+	server, err := NewServer(nodeID, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := newGRPCServer(server)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return idservice.NewIDServiceClient(conn)
+}
+
+// checkIDMessage verifies that every representation in msg describes the same ID
+func checkIDMessage(t *testing.T, msg *idservice.GenerateResponse, wantType uint32, wantNode int64) arbiterid.ID {
+	t.Helper()
+	id := arbiterid.ID(msg.GetId())
+	fromBytes, err := arbiterid.IDFromProtoBytes(msg.GetIdBytes())
+	if err != nil {
+		t.Fatalf("IDFromProtoBytes(%x) failed: %v", msg.GetIdBytes(), err)
+	}
+	fromBase58, err := arbiterid.ParseBase58(msg.GetBase58())
+	if err != nil {
+		t.Fatalf("ParseBase58(%s) failed: %v", msg.GetBase58(), err)
+	}
+	if fromBytes != id || fromBase58 != id {
+		t.Errorf("Representations disagree: id %d, bytes %d, base58 %d", id, fromBytes, fromBase58)
+	}
+	if msg.GetType() != wantType || msg.GetNode() != wantNode || msg.GetSequence() != id.Seq() || msg.GetTime() != id.TimeISO() {
+		t.Errorf("Unexpected components in %v for ID %d", msg, id)
+	}
+	return id
+}
+
+func TestGRPC_Generate(t *testing.T) {
+	client := newTestClient(t, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Generate(ctx, &idservice.GenerateRequest{Type: 512})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if id := checkIDMessage(t, resp, 512, 2); !id.IsValid() {
+		t.Errorf("Generated ID %d is not valid", id)
+	}
+
+	_, err = client.Generate(ctx, &idservice.GenerateRequest{Type: 1024})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for type 1024, got %v", err)
+	}
+}
+
+func TestGRPC_GenerateStream(t *testing.T) {
+	client := newTestClient(t, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const count = 2500
+	stream, err := client.GenerateStream(ctx, &idservice.GenerateStreamRequest{Type: 7, Count: count})
+	if err != nil {
+		t.Fatalf("GenerateStream failed: %v", err)
+	}
+	var received int
+	var last arbiterid.ID
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv failed after %d IDs: %v", received, err)
+		}
+		id := checkIDMessage(t, msg, 7, 1)
+		if id <= last {
+			t.Fatalf("ID %d (%d) is not greater than the previous %d", received, id, last)
+		}
+		last = id
+		received++
+	}
+	if received != count {
+		t.Errorf("Received %d IDs, want %d", received, count)
+	}
+
+	for _, req := range []*idservice.GenerateStreamRequest{{Type: 1, Count: 0}, {Type: 1, Count: maxStreamCount + 1}, {Type: 2000, Count: 1}} {
+		stream, err := client.GenerateStream(ctx, req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
@@ -1,21 +1,41 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/githonllc/arbiterid"
+	"google.golang.org/grpc"
 )
 
 // Server represents the ID generation service
 type Server struct {
-	node *arbiterid.Node
-	port string
+	node           *arbiterid.Node
+	nodeID         int
+	port           string
+	grpcPort       string // Empty disables the gRPC server
+	streamMaxCount int    // Largest count accepted by GET /generate/stream
+	statePath      string // File the node state is saved to on shutdown; empty disables it
+
+	httpServer *http.Server
+	inFlight   atomic.Int64 // HTTP requests being served
+
+	mu         sync.Mutex
+	grpcServer *grpc.Server // Set once the gRPC server is started
+
+	genMu  sync.RWMutex // Held for reading while generating; Shutdown takes it to stop generation
+	closed bool         // Set by Shutdown before saving state; generate refuses once set
 }
 
 // GenerateRequest represents the request payload for ID generation
@@ -43,20 +63,56 @@ type IDData struct {
 	Sequence int64  `json:"sequence"`  // Sequence number
 }
 
-// NewServer creates a new ID generation server
-func NewServer(nodeID int, port string) (*Server, error) {
+// decodeEncodings maps the encoding query parameter of /decode to an encoding
+var decodeEncodings = map[string]arbiterid.EncodingKind{
+	"decimal": arbiterid.EncodingDecimal,
+	"base32":  arbiterid.EncodingBase32,
+	"base58":  arbiterid.EncodingBase58,
+	"base64":  arbiterid.EncodingBase64,
+}
+
+// newIDData builds the response representation of an ID
+func newIDData(id arbiterid.ID) IDData {
+	idType, _, node, seq := id.Components()
+	return IDData{
+		ID:       id.Base58(),
+		IDInt64:  id.Int64(),
+		IDBase64: id.Base64(),
+		IDHex:    id.Hex(),
+		Type:     int(idType),
+		Time:     id.TimeISO(),
+		Node:     node,
+		Sequence: seq,
+	}
+}
+
+// nodeOptions returns the options the service creates its node with
+func nodeOptions() []arbiterid.NodeOption {
 	// Use quiet mode for production service
-	node, err := arbiterid.NewNode(nodeID,
+	return []arbiterid.NodeOption{
 		arbiterid.WithStrictMonotonicityCheck(true),
-		arbiterid.WithQuietMode(true))
+		arbiterid.WithQuietMode(true),
+	}
+}
+
+// NewServer creates a new ID generation server
+func NewServer(nodeID int, port string) (*Server, error) {
+	node, err := arbiterid.NewNode(nodeID, nodeOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create arbiterid node: %w", err)
 	}
 
-	return &Server{
-		node: node,
-		port: port,
-	}, nil
+	s := &Server{
+		node:           node,
+		nodeID:         nodeID,
+		port:           port,
+		streamMaxCount: defaultStreamMaxCount,
+	}
+	s.httpServer = &http.Server{
+		Addr:    ":" + port,
+		Handler: s.trackInFlight(http.DefaultServeMux),
+	}
+	return s, nil
 }
 
 // generateHandler handles POST /generate requests
@@ -109,23 +165,17 @@ func (s *Server) generateHandler(w http.ResponseWriter, r *http.Request) {
 	// Generate IDs
 	var results []IDData
 	for i := 0; i < count; i++ {
-		id, err := s.node.Generate(arbiterid.IDType(idType))
+		id, err := s.generate(r.Context(), arbiterid.IDType(idType))
+		if errors.Is(err, errShuttingDown) {
+			s.sendError(w, http.StatusServiceUnavailable, "Server is shutting down")
+			return
+		}
 		if err != nil {
 			s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to generate ID: %v", err))
 			return
 		}
 
-		idType, _, node, seq := id.Components()
-		results = append(results, IDData{
-			ID:       id.Base58(),
-			IDInt64:  id.Int64(),
-			IDBase64: id.Base64(),
-			IDHex:    fmt.Sprintf("%x", id.Int64()),
-			Type:     int(idType),
-			Time:     id.TimeISO(),
-			Node:     node,
-			Sequence: seq,
-		})
+		results = append(results, newIDData(id))
 	}
 
 	// Send response
@@ -139,6 +189,41 @@ func (s *Server) generateHandler(w http.ResponseWriter, r *http.Request) {
 	s.sendSuccess(w, data)
 }
 
+// decodeHandler handles GET /decode requests
+func (s *Server) decodeHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
+		return
+	}
+
+	value := r.URL.Query().Get("id")
+	if value == "" {
+		s.sendError(w, http.StatusBadRequest, "Missing id query parameter")
+		return
+	}
+
+	// Base58 is the default since it is the primary representation returned by /generate
+	encodingName := r.URL.Query().Get("encoding")
+	if encodingName == "" {
+		encodingName = "base58"
+	}
+	encoding, ok := decodeEncodings[encodingName]
+	if !ok {
+		s.sendError(w, http.StatusBadRequest, "Encoding must be one of decimal, base32, base58, base64")
+		return
+	}
+
+	id, err := arbiterid.ParseAndValidate(value, encoding)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.sendSuccess(w, newIDData(id))
+}
+
 // healthHandler handles GET /health requests
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -149,7 +234,11 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate a test ID to verify the service is working
-	testID, err := s.node.Generate(0)
+	testID, err := s.generate(r.Context(), 0)
+	if errors.Is(err, errShuttingDown) {
+		s.sendError(w, http.StatusServiceUnavailable, "Server is shutting down")
+		return
+	}
 	if err != nil {
 		s.sendError(w, http.StatusInternalServerError, "Service unhealthy: failed to generate test ID")
 		return
@@ -187,15 +276,36 @@ func (s *Server) infoHandler(w http.ResponseWriter, r *http.Request) {
 			"sequence":  "10 bits (0-1023)",
 		},
 		"endpoints": map[string]string{
-			"POST /generate": "Generate new ID(s)",
-			"GET /health":    "Health check",
-			"GET /info":      "Service information",
+			"POST /generate":       "Generate new ID(s)",
+			"GET /generate/stream": "Stream many IDs as NDJSON",
+			"GET /decode":          "Decode and validate an ID",
+			"GET /health":          "Health check",
+			"GET /info":            "Service information",
+			"GET /config":          "Node configuration",
 		},
 	}
 
 	s.sendSuccess(w, response)
 }
 
+// configHandler handles GET /config requests
+func (s *Server) configHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
+		return
+	}
+
+	config, err := s.node.ConfigJSON()
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to export node configuration: %v", err))
+		return
+	}
+
+	s.sendSuccess(w, json.RawMessage(config))
+}
+
 // sendSuccess sends a successful JSON response
 func (s *Server) sendSuccess(w http.ResponseWriter, data interface{}) {
 	response := GenerateResponse{
@@ -219,8 +329,11 @@ func (s *Server) sendError(w http.ResponseWriter, statusCode int, message string
 // setupRoutes sets up HTTP routes
 func (s *Server) setupRoutes() {
 	http.HandleFunc("/generate", s.generateHandler)
+	http.HandleFunc("/generate/stream", s.generateStreamHandler)
+	http.HandleFunc("/decode", s.decodeHandler)
 	http.HandleFunc("/health", s.healthHandler)
 	http.HandleFunc("/info", s.infoHandler)
+	http.HandleFunc("/config", s.configHandler)
 
 	// Root handler provides basic info
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -232,19 +345,31 @@ func (s *Server) setupRoutes() {
 	})
 }
 
-// Start starts the HTTP server
+// Start starts the HTTP server, and the gRPC server if a gRPC port is configured. It
+// blocks until the server fails or Shutdown is called, returning nil in the latter case.
 func (s *Server) Start() error {
 	s.setupRoutes()
+	if s.grpcPort != "" {
+		if err := s.startGRPC(s.grpcPort); err != nil {
+			return err
+		}
+	}
 
 	log.Printf("Starting ArbiterID service on port %s", s.port)
 	log.Printf("Node ID: %d", s.node.LastID().Node())
 	log.Println("Available endpoints:")
 	log.Println("  POST /generate - Generate new ID(s)")
+	log.Println("  GET  /generate/stream - Stream many IDs as NDJSON")
+	log.Println("  GET  /decode   - Decode and validate an ID")
 	log.Println("  GET  /health   - Health check")
 	log.Println("  GET  /info     - Service information")
+	log.Println("  GET  /config   - Node configuration")
 	log.Println("  GET  /         - Service information")
 
-	return http.ListenAndServe(":"+s.port, nil)
+	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
 func main() {
@@ -270,7 +395,53 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
+	server.grpcPort = os.Getenv("GRPC_PORT")
+	if maxStr := os.Getenv("STREAM_MAX_COUNT"); maxStr != "" {
+		maxCount, err := strconv.Atoi(maxStr)
+		if err != nil || maxCount < 1 {
+			log.Fatalf("Invalid STREAM_MAX_COUNT: %s (must be a positive integer)", maxStr)
+		}
+		server.streamMaxCount = maxCount
+	}
+
+	server.statePath = os.Getenv("STATE_FILE")
+	if server.statePath != "" {
+		if err := server.restoreState(); err != nil {
+			log.Fatalf("Failed to restore node state: %v", err)
+		}
+	}
+
+	timeoutStr := os.Getenv("SHUTDOWN_TIMEOUT")
+	if timeoutStr == "" {
+		timeoutStr = defaultShutdownTimeout
+	}
+	shutdownTimeout, err := time.ParseDuration(timeoutStr)
+	if err != nil || shutdownTimeout <= 0 {
+		log.Fatalf("Invalid SHUTDOWN_TIMEOUT: %s (must be a positive duration such as 8s)", timeoutStr)
+	}
 
-	// Graceful shutdown would be nice, but keeping this simple for the example
-	log.Fatal(server.Start())
+	// Serve until SIGINT or SIGTERM, then drain in-flight requests
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Start() }()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	case <-ctx.Done():
+		stop() // A second signal kills the process immediately
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Shutdown incomplete: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		log.Printf("Server error during shutdown: %v", err)
+	}
+	log.Println("ArbiterID service stopped")
 }
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/githonllc/arbiterid"
+)
+
+// defaultShutdownTimeout bounds how long Shutdown waits for in-flight requests unless
+// SHUTDOWN_TIMEOUT overrides it; it stays under Docker's default 10s stop grace period
+const defaultShutdownTimeout = "8s"
+
+// trackInFlight counts requests being served, so Shutdown can report how many it drained
+func (s *Server) trackInFlight(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// errShuttingDown is returned by generate once Shutdown has stopped generation
+var errShuttingDown = errors.New("server is shutting down")
+
+// generate creates an ID unless the server is shutting down. Handlers generate through it
+// rather than the node directly, so no ID can be issued after Shutdown saves the state.
+func (s *Server) generate(ctx context.Context, idType arbiterid.IDType) (arbiterid.ID, error) {
+	s.genMu.RLock()
+	defer s.genMu.RUnlock()
+	if s.closed {
+		return 0, errShuttingDown
+	}
+	return s.node.GenerateContext(ctx, idType)
+}
+
+// Shutdown stops accepting connections and waits for in-flight HTTP requests and gRPC
+// calls to finish until ctx is done, when the remaining ones are cut off. Handlers cut
+// off that way may still be running, so it then waits for any generation in progress
+// and refuses further ones before saving the node state, if a state file is configured,
+// so a restart can resume after the last ID.
+func (s *Server) Shutdown(ctx context.Context) error {
+	draining := s.inFlight.Load()
+	log.Printf("Shutting down, draining %d in-flight request(s)", draining)
+
+	err := s.httpServer.Shutdown(ctx)
+	if err != nil {
+		log.Printf("Shutdown timed out with %d request(s) still in flight", s.inFlight.Load())
+		s.httpServer.Close()
+	} else {
+		log.Printf("Drained %d in-flight request(s)", draining)
+	}
+
+	s.mu.Lock()
+	gs := s.grpcServer
+	s.mu.Unlock()
+	if gs != nil {
+		stopped := make(chan struct{})
+		go func() {
+			gs.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			gs.Stop()
+		}
+	}
+
+	// Cut-off handlers may still be generating; wait for them and refuse new IDs
+	s.genMu.Lock()
+	s.closed = true
+	s.genMu.Unlock()
+
+	if s.statePath != "" {
+		if saveErr := s.saveState(); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		log.Printf("Saved node state to %s (last ID %d)", s.statePath, s.node.LastID())
+	}
+	return err
+}
+
+// saveState writes the node's state to the state file, replacing it atomically so a crash
+// mid-write cannot leave a truncated file behind
+func (s *Server) saveState() error {
+	data, err := json.MarshalIndent(s.node.State(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode node state: %w", err)
+	}
+	tmp := s.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write node state: %w", err)
+	}
+	if err := os.Rename(tmp, s.statePath); err != nil {
+		return fmt.Errorf("failed to write node state: %w", err)
+	}
+	return nil
+}
+
+// restoreState replaces the server's node with one resuming from the state file, if it
+// exists. It must be called before Start. A state saved by a different node ID is
+// rejected, since resuming from it would not protect this node's IDs.
+func (s *Server) restoreState() error {
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("No node state at %s, starting fresh", s.statePath)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read node state: %w", err)
+	}
+
+	var state arbiterid.NodeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to decode node state %s: %w", s.statePath, err)
+	}
+	if state.Node != int64(s.nodeID) {
+		return fmt.Errorf("node state %s belongs to node %d, not %d", s.statePath, state.Node, s.nodeID)
+	}
+	node, err := arbiterid.NewNodeFromState(state, nodeOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to restore node state: %w", err)
+	}
+	s.node = node
+	log.Printf("Restored node state from %s (last ID %d)", s.statePath, state.LastID)
+	return nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShutdown_SavesAndRestoresState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "node-state.json")
+
+	server, err := NewServer(1, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	server.statePath = statePath
+	for i := 0; i < 5; i++ {
+		if _, err := server.node.Generate(3); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+	last := server.node.LastID()
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	restarted, err := NewServer(1, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	restarted.statePath = statePath
+	if err := restarted.restoreState(); err != nil {
+		t.Fatalf("restoreState failed: %v", err)
+	}
+	if got := restarted.node.LastID(); got != last {
+		t.Errorf("Restored LastID = %d, want %d", got, last)
+	}
+	id, err := restarted.node.Generate(3)
+	if err != nil {
+		t.Fatalf("Generate after restore failed: %v", err)
+	}
+	if id <= last {
+		t.Errorf("ID %d after restore does not follow %d", id, last)
+	}
+
+	// The state file is tied to the node that wrote it
+	other, err := NewServer(2, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	other.statePath = statePath
+	if err := other.restoreState(); err == nil || !strings.Contains(err.Error(), "belongs to node 1") {
+		t.Errorf("Expected a node mismatch error, got %v", err)
+	}
+}
+
+func TestShutdown_StopsGenerationBeforeSaving(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "node-state.json")
+
+	server, err := NewServer(1, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	server.statePath = statePath
+
+	// A handler cut off by the shutdown timeout is still generating
+	server.genMu.RLock()
+	done := make(chan error, 1)
+	go func() { done <- server.Shutdown(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned while generation was in progress: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	last, err := server.node.Generate(3)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	server.genMu.RUnlock()
+	if err := <-done; err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	// The saved state covers the late ID, and nothing is generated after it
+	if _, err := server.generate(context.Background(), 3); !errors.Is(err, errShuttingDown) {
+		t.Errorf("Expected errShuttingDown after Shutdown, got %v", err)
+	}
+	rec := httptest.NewRecorder()
+	server.generateHandler(rec, httptest.NewRequest(http.MethodPost, "/generate", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 from /generate after Shutdown, got %d: %s", rec.Code, rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	server.healthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 from /health after Shutdown, got %d: %s", rec.Code, rec.Body)
+	}
+
+	restarted, err := NewServer(1, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	restarted.statePath = statePath
+	if err := restarted.restoreState(); err != nil {
+		t.Fatalf("restoreState failed: %v", err)
+	}
+	if got := restarted.node.LastID(); got != last {
+		t.Errorf("Restored LastID = %d, want the late ID %d", got, last)
+	}
+}
+
+func TestRestoreState_MissingFile(t *testing.T) {
+	server, err := NewServer(0, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	server.statePath = filepath.Join(t.TempDir(), "missing.json")
+	if err := server.restoreState(); err != nil {
+		t.Errorf("Expected a fresh start without a state file, got %v", err)
+	}
+
+	if err := os.WriteFile(server.statePath, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.restoreState(); err == nil {
+		t.Error("Expected an error for a corrupt state file")
+	}
+}
+
+func TestTrackInFlight(t *testing.T) {
+	server, err := NewServer(0, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	var during int64
+	handler := server.trackInFlight(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		during = server.inFlight.Load()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	if during != 1 {
+		t.Errorf("Expected 1 request in flight while serving, got %d", during)
+	}
+	if got := server.inFlight.Load(); got != 0 {
+		t.Errorf("Expected no requests in flight afterwards, got %d", got)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/githonllc/arbiterid"
+)
+
+// defaultStreamMaxCount caps GET /generate/stream unless STREAM_MAX_COUNT overrides it
+const defaultStreamMaxCount = 1000000
+
+// streamFlushInterval is how many IDs are written between flushes of a stream
+const streamFlushInterval = 1000
+
+// generateStreamHandler handles GET /generate/stream requests, writing one JSON object per
+// line (NDJSON) as IDs are generated, so large counts need neither a large response buffer
+// nor the client to wait for the whole batch. Generation stops when the client goes away.
+func (s *Server) generateStreamHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "Only GET method is allowed")
+		return
+	}
+
+	idType := 0
+	if idTypeStr := r.URL.Query().Get("type"); idTypeStr != "" {
+		parsed, err := strconv.Atoi(idTypeStr)
+		if err != nil || parsed < 0 || parsed > int(arbiterid.TypeMax) {
+			s.sendError(w, http.StatusBadRequest, fmt.Sprintf("ID type must be between 0 and %d", arbiterid.TypeMax))
+			return
+		}
+		idType = parsed
+	}
+
+	count := 1
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		parsed, err := strconv.Atoi(countStr)
+		if err != nil || parsed < 1 || parsed > s.streamMaxCount {
+			s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Count must be between 1 and %d", s.streamMaxCount))
+			return
+		}
+		count = parsed
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	for i := 1; i <= count; i++ {
+		if ctx.Err() != nil {
+			return
+		}
+		id, err := s.generate(ctx, arbiterid.IDType(idType))
+		if err != nil {
+			// The status is already sent, so report the failure as the last line
+			if ctx.Err() == nil {
+				enc.Encode(GenerateResponse{Success: false, Error: fmt.Sprintf("Failed to generate ID: %v", err)})
+			}
+			return
+		}
+		if err := enc.Encode(newIDData(id)); err != nil {
+			return // Client disconnected
+		}
+		if i%streamFlushInterval == 0 {
+			rc.Flush()
+		}
+	}
+	rc.Flush()
+}
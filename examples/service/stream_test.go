@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/githonllc/arbiterid"
+)
+
+func TestGenerateStreamHandler(t *testing.T) {
+	server, err := NewServer(2, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	const count = 2500
+	rec := httptest.NewRecorder()
+	server.generateStreamHandler(rec, httptest.NewRequest(http.MethodGet, "/generate/stream?type=7&count=2500", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+	if !rec.Flushed {
+		t.Error("Expected the stream to be flushed")
+	}
+
+	var (
+		lines int
+		last  int64
+	)
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var data IDData
+		if err := json.Unmarshal(scanner.Bytes(), &data); err != nil {
+			t.Fatalf("Line %d is not an ID: %v: %s", lines+1, err, scanner.Text())
+		}
+		if data.Type != 7 || data.Node != 2 || data.IDInt64 <= last {
+			t.Fatalf("Line %d: unexpected ID %+v after %d", lines+1, data, last)
+		}
+		last = data.IDInt64
+		lines++
+	}
+	if lines != count {
+		t.Errorf("Expected %d lines, got %d", count, lines)
+	}
+}
+
+func TestGenerateStreamHandler_Errors(t *testing.T) {
+	server, err := NewServer(0, "0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	server.streamMaxCount = 10
+
+	tests := []struct {
+		name, method, target string
+		want                 int
+	}{
+		{"Method", http.MethodPost, "/generate/stream", http.StatusMethodNotAllowed},
+		{"CountOverMax", http.MethodGet, "/generate/stream?count=11", http.StatusBadRequest},
+		{"CountZero", http.MethodGet, "/generate/stream?count=0", http.StatusBadRequest},
+		{"Type", http.MethodGet, "/generate/stream?type=1024", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			server.generateStreamHandler(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if rec.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		rec := httptest.NewRecorder()
+		server.generateStreamHandler(rec, httptest.NewRequest(http.MethodGet, "/generate/stream?count=10", nil).WithContext(ctx))
+		if rec.Body.Len() != 0 {
+			t.Errorf("Expected no IDs for a cancelled request, got %q", rec.Body)
+		}
+		if last := server.node.LastID(); last != arbiterid.ID(0) {
+			t.Errorf("Expected no IDs to be generated, last ID is %d", last)
+		}
+	})
+}
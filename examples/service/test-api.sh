@@ -67,4 +67,23 @@ curl -s -X GET "${BASE_URL}/generate" | jq '.' 2>/dev/null || curl -s -X GET "${
 echo
 echo
 
+# Decode a generated ID
+echo "9. Decode a generated ID..."
+GENERATED_ID=$(curl -s -X POST "${BASE_URL}/generate" | jq -r '.data.id' 2>/dev/null)
+curl -s "${BASE_URL}/decode?id=${GENERATED_ID}&encoding=base58" | jq '.' 2>/dev/null || curl -s "${BASE_URL}/decode?id=${GENERATED_ID}&encoding=base58"
+echo
+echo
+
+# Decode a malformed ID
+echo "10. Test decoding a malformed ID..."
+curl -s "${BASE_URL}/decode?id=not-an-id" | jq '.' 2>/dev/null || curl -s "${BASE_URL}/decode?id=not-an-id"
+echo
+echo
+
+# Get node configuration
+echo "11. Get node configuration..."
+curl -s "${BASE_URL}/config" | jq '.' 2>/dev/null || curl -s "${BASE_URL}/config"
+echo
+echo
+
 echo "=== Test Complete ==="
\ No newline at end of file
@@ -22,6 +22,13 @@ func main() {
 	const PostIDType arbiterid.IDType = 512
 	const CommentIDType arbiterid.IDType = 256
 
+	// Name them so ID.TypeName can show "user" rather than 1
+	for idType, name := range map[arbiterid.IDType]string{UserIDType: "user", PostIDType: "post", CommentIDType: "comment"} {
+		if err := arbiterid.RegisterType(idType, name); err != nil {
+			log.Fatalf("Failed to register type %d: %v", idType, err)
+		}
+	}
+
 	fmt.Println("=== ArbiterID Examples ===")
 
 	// Example 1: Generate User ID
@@ -49,7 +56,7 @@ func main() {
 	// Example 3: Extract components
 	fmt.Println("\n3. Extracting ID Components:")
 	IDType, tsMillis, nodeID, seq := userID.Components()
-	fmt.Printf("   Type:      %d\n", IDType)
+	fmt.Printf("   Type:      %d (%s)\n", IDType, userID.TypeName())
 	fmt.Printf("   Timestamp: %d ms (since epoch)\n", tsMillis)
 	fmt.Printf("   Node ID:   %d\n", nodeID)
 	fmt.Printf("   Sequence:  %d\n", seq)
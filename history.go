@@ -0,0 +1,52 @@
+package arbiterid
+
+// recentHistory is a fixed-size ring buffer of the most recently generated IDs.
+type recentHistory struct {
+	ids  []ID
+	next int  // Index the next ID is written to
+	full bool // Whether the buffer has wrapped at least once
+}
+
+// add records id, overwriting the oldest entry once the buffer is full.
+func (h *recentHistory) add(id ID) {
+	h.ids[h.next] = id
+	h.next++
+	if h.next == len(h.ids) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// snapshot returns the recorded IDs from oldest to newest.
+func (h *recentHistory) snapshot() []ID {
+	if !h.full {
+		return append([]ID(nil), h.ids[:h.next]...)
+	}
+	out := make([]ID, 0, len(h.ids))
+	out = append(out, h.ids[h.next:]...)
+	return append(out, h.ids[:h.next]...)
+}
+
+// WithRecentHistory keeps the last size generated IDs in a ring buffer, available through
+// RecentIDs. This gives immediate context when investigating a suspected duplicate.
+// Default is 0, which keeps no history.
+func WithRecentHistory(size int) NodeOption {
+	return func(n *Node) {
+		if size <= 0 {
+			n.history = nil
+			return
+		}
+		n.history = &recentHistory{ids: make([]ID, size)}
+	}
+}
+
+// RecentIDs returns up to the last size IDs generated by this node, oldest first,
+// where size is the value given to WithRecentHistory. It returns nil when history is disabled.
+func (n *Node) RecentIDs() []ID {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.history == nil {
+		return nil
+	}
+	return n.history.snapshot()
+}
@@ -0,0 +1,46 @@
+package arbiterid
+
+import "testing"
+
+func TestRecentIDs(t *testing.T) {
+	const size = 5
+
+	t.Run("Partially filled", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithRecentHistory(size))
+		if got := node.RecentIDs(); len(got) != 0 {
+			t.Errorf("Expected empty history before generation, got %v", got)
+		}
+		first := node.GenerateSimple(testType1)
+		second := node.GenerateSimple(testType1)
+		got := node.RecentIDs()
+		if len(got) != 2 || got[0] != first || got[1] != second {
+			t.Errorf("RecentIDs() = %v, want [%d %d]", got, first, second)
+		}
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithRecentHistory(size))
+		var generated []ID
+		for i := 0; i < size*3+2; i++ {
+			generated = append(generated, node.GenerateSimple(testType1))
+		}
+		want := generated[len(generated)-size:]
+		got := node.RecentIDs()
+		if len(got) != size {
+			t.Fatalf("Expected %d recent IDs, got %d", size, len(got))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("RecentIDs()[%d] = %d, want %d", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		node.GenerateSimple(testType1)
+		if got := node.RecentIDs(); got != nil {
+			t.Errorf("Expected nil history when disabled, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package arbiterid
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Idempotency errors
+var (
+	ErrNoIdempotencyCache  = errors.New("arbiterid: node was not created with WithIdempotencyCache")
+	ErrIdempotencyConflict = errors.New("arbiterid: idempotency token already used for a different type")
+)
+
+// idempotencyCache is a bounded LRU map from idempotency token to the ID minted for it.
+type idempotencyCache struct {
+	size  int
+	order *list.List               // Front is most recently used; values are *idempotencyEntry
+	items map[string]*list.Element // Token to its element in order
+}
+
+type idempotencyEntry struct {
+	token  string
+	id     ID
+	idType IDType // Requested type; id.Type differs with WithBitLayout or WithRegionCode
+}
+
+// get returns the entry cached for token, marking it most recently used.
+func (c *idempotencyCache) get(token string) (*idempotencyEntry, bool) {
+	el, ok := c.items[token]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*idempotencyEntry), true
+}
+
+// put caches id, minted for idType, under token, evicting the least recently used token
+// when full.
+func (c *idempotencyCache) put(token string, idType IDType, id ID) {
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*idempotencyEntry).token)
+	}
+	c.items[token] = c.order.PushFront(&idempotencyEntry{token: token, id: id, idType: idType})
+}
+
+// WithIdempotencyCache enables GenerateIdempotent, remembering the IDs minted for the
+// size most recently used tokens. Default is 0, which disables the cache.
+func WithIdempotencyCache(size int) NodeOption {
+	return func(n *Node) {
+		if size <= 0 {
+			n.idempotency = nil
+			return
+		}
+		n.idempotency = &idempotencyCache{size: size, order: list.New(), items: make(map[string]*list.Element, size)}
+	}
+}
+
+// GenerateIdempotent returns the ID previously minted for token if it is still cached,
+// and otherwise generates a new ID and caches it under token. Retried requests that
+// carry the same idempotency token thus get the same ID rather than creating duplicate
+// records, as long as the token has not been evicted from the cache.
+//
+// Reusing a cached token with a different type returns ErrIdempotencyConflict. Without
+// WithIdempotencyCache, it returns ErrNoIdempotencyCache.
+func (n *Node) GenerateIdempotent(idType IDType, token string) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.idempotency == nil {
+		return 0, ErrNoIdempotencyCache
+	}
+	if entry, ok := n.idempotency.get(token); ok {
+		if entry.idType != idType {
+			return 0, fmt.Errorf("%w: token %q holds ID %d of type %d, requested type %d",
+				ErrIdempotencyConflict, token, entry.id, entry.idType, idType)
+		}
+		return entry.id, nil
+	}
+
+	id, err := n.generateLocked(context.Background(), idType)
+	if err != nil {
+		return 0, err
+	}
+	n.idempotency.put(token, idType, id)
+	return id, nil
+}
@@ -0,0 +1,72 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateIdempotent(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithIdempotencyCache(2))
+
+	first, err := node.GenerateIdempotent(testType1, "req-1")
+	if err != nil {
+		t.Fatalf("GenerateIdempotent failed: %v", err)
+	}
+	again, err := node.GenerateIdempotent(testType1, "req-1")
+	if err != nil || again != first {
+		t.Errorf("Retry with same token = %d, %v; want %d", again, err, first)
+	}
+
+	other, err := node.GenerateIdempotent(testType1, "req-2")
+	if err != nil {
+		t.Fatalf("GenerateIdempotent failed: %v", err)
+	}
+	if other == first {
+		t.Error("Different tokens should get different IDs")
+	}
+
+	if _, err := node.GenerateIdempotent(testType0, "req-1"); !errors.Is(err, ErrIdempotencyConflict) {
+		t.Errorf("Expected ErrIdempotencyConflict, got %v", err)
+	}
+
+	// req-1 was used more recently than req-2, so req-3 evicts req-2
+	if _, err := node.GenerateIdempotent(testType1, "req-3"); err != nil {
+		t.Fatalf("GenerateIdempotent failed: %v", err)
+	}
+	if id, _ := node.GenerateIdempotent(testType1, "req-1"); id != first {
+		t.Errorf("req-1 should still be cached as %d, got %d", first, id)
+	}
+	if id, _ := node.GenerateIdempotent(testType1, "req-2"); id == other {
+		t.Error("req-2 should have been evicted and minted a new ID")
+	}
+}
+
+func TestGenerateIdempotent_Disabled(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	if _, err := node.GenerateIdempotent(testType1, "req-1"); !errors.Is(err, ErrNoIdempotencyCache) {
+		t.Errorf("Expected ErrNoIdempotencyCache, got %v", err)
+	}
+}
+
+func TestGenerateIdempotent_TypeLayouts(t *testing.T) {
+	for name, opt := range map[string]NodeOption{
+		"layout": WithBitLayout(6, 2, 16),
+		"region": WithRegionCode(3),
+	} {
+		t.Run(name, func(t *testing.T) {
+			node := newTestNode(t, testNodeID0, WithQuietMode(true), WithIdempotencyCache(4), opt)
+
+			first, err := node.GenerateIdempotent(5, "req-1")
+			if err != nil {
+				t.Fatalf("GenerateIdempotent failed: %v", err)
+			}
+			again, err := node.GenerateIdempotent(5, "req-1")
+			if err != nil || again != first {
+				t.Errorf("Retry with same token = %d, %v; want %d", again, err, first)
+			}
+			if _, err := node.GenerateIdempotent(6, "req-1"); !errors.Is(err, ErrIdempotencyConflict) {
+				t.Errorf("Expected ErrIdempotencyConflict, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package arbiterid
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// GenerateAndLog creates a new ID and writes its decimal form followed by a newline to w,
+// for append-only ID logs. The write happens under the node's mutex, so concurrent calls
+// sharing a writer log IDs in generation order; a slow writer therefore delays all
+// generation on the node.
+//
+// If the write fails, the error is returned with a zero ID. The node stays consistent:
+// the unlogged ID is simply never handed out, and the next ID still sorts after it.
+func (n *Node) GenerateAndLog(idType IDType, w io.Writer) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	id, err := n.generateLocked(context.Background(), idType)
+	if err != nil {
+		return 0, err
+	}
+
+	var buf [maxDecimal + 1]byte
+	line := append(strconv.AppendInt(buf[:0], int64(id), 10), '\n')
+	if _, err := w.Write(line); err != nil {
+		return 0, fmt.Errorf("arbiterid: failed to log ID %d: %w", id, err)
+	}
+	return id, nil
+}
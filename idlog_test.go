@@ -0,0 +1,60 @@
+package arbiterid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAndLog(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	var buf bytes.Buffer
+	var want []string
+	for i := 0; i < 10; i++ {
+		id, err := node.GenerateAndLog(testType1, &buf)
+		if err != nil {
+			t.Fatalf("GenerateAndLog failed: %v", err)
+		}
+		want = append(want, id.String())
+	}
+
+	if got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Logged IDs %v, want %v", got, want)
+	}
+	if !strings.HasSuffix(buf.String(), "\n") {
+		t.Error("Log should end with a newline")
+	}
+}
+
+type failingWriter struct{}
+
+var errWriteFailed = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWriteFailed }
+
+func TestGenerateAndLog_WriteError(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	before := node.GenerateSimple(testType1)
+
+	id, err := node.GenerateAndLog(testType1, failingWriter{})
+	if !errors.Is(err, errWriteFailed) {
+		t.Errorf("Expected write error, got %v", err)
+	}
+	if id != 0 {
+		t.Errorf("Expected zero ID on write error, got %d", id)
+	}
+
+	// The unlogged ID was consumed; the node keeps generating unique, increasing IDs
+	unlogged := node.LastID()
+	if unlogged <= before {
+		t.Errorf("Unlogged ID %d not greater than %d", unlogged, before)
+	}
+	if after := node.GenerateSimple(testType1); after <= unlogged {
+		t.Errorf("ID after failed log %d not greater than %d", after, unlogged)
+	}
+	if _, err := node.GenerateAndLog(IDType(TypeMax+1), &bytes.Buffer{}); err == nil {
+		t.Error("Expected error for invalid type")
+	}
+}
@@ -0,0 +1,96 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidLayout is returned for a Layout whose sections do not fill exactly 63 bits.
+var ErrInvalidLayout = errors.New("arbiterid: invalid bit layout")
+
+// Layout gives the width in bits of each ID section, from most to least significant.
+// The widths must sum to 63 so that IDs stay positive.
+type Layout struct {
+	TypeBits      uint8 `json:"type_bits"`
+	TimestampBits uint8 `json:"timestamp_bits"`
+	NodeBits      uint8 `json:"node_bits"`
+	SeqBits       uint8 `json:"seq_bits"`
+}
+
+// DefaultLayout is the layout described by the package constants: 10 type bits, 41
+// timestamp bits, 2 node bits, and 10 sequence bits.
+var DefaultLayout = Layout{
+	TypeBits:      TypeBits,
+	TimestampBits: TimestampBits,
+	NodeBits:      NodeBits,
+	SeqBits:       SeqBits,
+}
+
+// CompactLayout is a preset for deployments with few types but high throughput: 4 type
+// bits (types 0-15) and 16 sequence bits (65536 IDs per node per millisecond), keeping the
+// default timestamp and node widths. See WithCompactLayout.
+var CompactLayout = Layout{
+	TypeBits:      4,
+	TimestampBits: TimestampBits,
+	NodeBits:      NodeBits,
+	SeqBits:       SeqBits + 6,
+}
+
+// Validate checks that every section is at least one bit wide, that they sum to 63, and
+// that the type fits in an IDType.
+func (l Layout) Validate() error {
+	if l.TypeBits == 0 || l.TimestampBits == 0 || l.NodeBits == 0 || l.SeqBits == 0 {
+		return fmt.Errorf("%w: every section needs at least one bit, got %+v", ErrInvalidLayout, l)
+	}
+	if l.TypeBits > 16 {
+		return fmt.Errorf("%w: %d type bits do not fit in IDType's 16", ErrInvalidLayout, l.TypeBits)
+	}
+	if total := int(l.TypeBits) + int(l.TimestampBits) + int(l.NodeBits) + int(l.SeqBits); total != 63 {
+		return fmt.Errorf("%w: sections sum to %d bits, expected 63", ErrInvalidLayout, total)
+	}
+	return nil
+}
+
+// Shifts and maxima derived from the widths
+func (l Layout) nodeShift() uint8 { return l.SeqBits }
+func (l Layout) timeShift() uint8 { return l.SeqBits + l.NodeBits }
+func (l Layout) typeShift() uint8 { return l.SeqBits + l.NodeBits + l.TimestampBits }
+func (l Layout) typeMax() int64   { return 1<<l.TypeBits - 1 }
+func (l Layout) timeMax() int64   { return 1<<l.TimestampBits - 1 }
+func (l Layout) nodeMax() int64   { return 1<<l.NodeBits - 1 }
+func (l Layout) seqMax() int64    { return 1<<l.SeqBits - 1 }
+func (l Layout) typeMask() int64  { return l.typeMax() << l.typeShift() }
+
+// WithBitLayout replaces the default 10/41/2/10 layout, e.g. to trade timestamp or
+// sequence bits for more node bits once a fleet outgrows four nodes. The timestamp gets
+// whatever remains of the 63 bits; NewNode returns ErrInvalidLayout if nothing does, and
+// validates the node ID against the wider or narrower node field.
+//
+// IDs from such a node no longer match the package constants: decode them with
+// Node.Decoder (or NewDecoderOnly with the same layout) rather than ID.Components and
+// the other ID methods, and expect a shorter lifetime than ~69 years from fewer
+// timestamp bits. Nodes with different layouts must not share an ID space.
+func WithBitLayout(typeBits, nodeBits, seqBits uint8) NodeOption {
+	return func(n *Node) {
+		n.layout = Layout{TypeBits: typeBits, NodeBits: nodeBits, SeqBits: seqBits}
+		if used := int(typeBits) + int(nodeBits) + int(seqBits); used < 63 {
+			n.layout.TimestampBits = uint8(63 - used)
+		}
+	}
+}
+
+// WithCompactLayout switches the node to CompactLayout, trading six type bits for
+// sequence bits so one node can issue 64 times as many IDs per millisecond. As with
+// WithBitLayout, the IDs no longer match the package constants: decode them with
+// Node.Decoder or NewDecoderOnly(Epoch, CompactLayout). The lifetime is unchanged.
+func WithCompactLayout() NodeOption {
+	return func(n *Node) {
+		n.layout = CompactLayout
+	}
+}
+
+// Layout returns the node's bit layout: DefaultLayout unless WithBitLayout or
+// WithCompactLayout was given.
+func (n *Node) Layout() Layout {
+	return n.layout
+}
@@ -0,0 +1,126 @@
+package arbiterid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithBitLayout(t *testing.T) {
+	// 8 node bits for up to 256 nodes, paid for with type and sequence bits
+	const typeBits, nodeBits, seqBits = 8, 8, 6
+	start := time.UnixMilli(Epoch + 123_456).UTC()
+	clock := NewManualClock(start)
+	node := newTestNode(t, 200, WithQuietMode(true), WithManualClock(clock), WithSelfCheck(true),
+		WithBitLayout(typeBits, nodeBits, seqBits))
+
+	want := Layout{TypeBits: typeBits, TimestampBits: 41, NodeBits: nodeBits, SeqBits: seqBits}
+	if node.Layout() != want {
+		t.Fatalf("Layout() = %+v, want %+v", node.Layout(), want)
+	}
+
+	// More IDs than one millisecond's 64 sequences, spread over several milliseconds
+	ids, err := node.GenerateBatch(IDType(255), 150)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	if _, err := VerifyMonotonic(ids); err != nil {
+		t.Error(err)
+	}
+	d := node.Decoder()
+	for i, id := range ids {
+		idType, ts, nodeID, seq := d.Components(id)
+		wantTS := start.UnixMilli() + int64(i/64)
+		if idType != 255 || ts != wantTS || nodeID != 200 || seq != int64(i%64) {
+			t.Fatalf("ID %d decoded to type %d, time %d, node %d, seq %d; want 255, %d, 200, %d",
+				i, idType, ts, nodeID, seq, wantTS, i%64)
+		}
+	}
+
+	if _, err := node.Generate(IDType(256)); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType for a type wider than %d bits, got %v", typeBits, err)
+	}
+
+	// The exported configuration carries the layout and rebuilds the same node
+	exported, err := node.ConfigJSON()
+	if err != nil {
+		t.Fatalf("ConfigJSON failed: %v", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(exported, &cfg); err != nil {
+		t.Fatalf("Unmarshal ConfigJSON output failed: %v", err)
+	}
+	rebuilt, err := NewNodeFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewNodeFromConfig failed: %v", err)
+	}
+	if rebuilt.Layout() != want {
+		t.Errorf("Rebuilt node layout = %+v, want %+v", rebuilt.Layout(), want)
+	}
+}
+
+func TestWithBitLayout_Invalid(t *testing.T) {
+	tests := []struct {
+		name                        string
+		nodeID                      int
+		typeBits, nodeBits, seqBits uint8
+		wantErr                     error
+	}{
+		{"NoTimestampBitsLeft", 0, 10, 13, 40, ErrInvalidLayout},
+		{"Overfull", 0, 30, 30, 30, ErrInvalidLayout},
+		{"ZeroNodeBits", 0, 10, 0, 10, ErrInvalidLayout},
+		{"TypeWiderThanIDType", 0, 17, 2, 10, ErrInvalidLayout},
+		{"NodeIDTooLarge", 256, 8, 8, 6, ErrInvalidNodeID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNode(tt.nodeID, WithQuietMode(true), WithBitLayout(tt.typeBits, tt.nodeBits, tt.seqBits))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// GenerateForKey needs room for its shard bits in the sequence
+	node := newTestNode(t, 0, WithQuietMode(true), WithBitLayout(10, 10, 3))
+	if _, err := node.GenerateForKey(testType1, []byte("key")); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("Expected ErrInvalidLayout from GenerateForKey, got %v", err)
+	}
+}
+
+func TestWithCompactLayout(t *testing.T) {
+	start := time.UnixMilli(Epoch + 123_456).UTC()
+	node := newTestNode(t, testNodeID1, WithQuietMode(true), WithManualClock(NewManualClock(start)),
+		WithSelfCheck(true), WithCompactLayout())
+	if node.Layout() != CompactLayout {
+		t.Fatalf("Layout() = %+v, want %+v", node.Layout(), CompactLayout)
+	}
+
+	// Far more IDs than the default SeqMax, all in the one pinned millisecond
+	const count = 4 * (SeqMax + 1)
+	d := NewDecoderOnly(Epoch, CompactLayout)
+	var last ID
+	for i := int64(0); i < count; i++ {
+		id, err := node.Generate(IDType(15))
+		if err != nil {
+			t.Fatalf("Generate failed at iteration %d: %v", i, err)
+		}
+		if id <= last {
+			t.Fatalf("ID %d not greater than previous %d", id, last)
+		}
+		last = id
+		idType, ts, nodeID, seq := d.Components(id)
+		if idType != 15 || ts != start.UnixMilli() || nodeID != testNodeID1 || seq != i {
+			t.Fatalf("ID %d decoded to type %d, time %d, node %d, seq %d; want 15, %d, %d, %d",
+				i, idType, ts, nodeID, seq, start.UnixMilli(), testNodeID1, i)
+		}
+	}
+	if got := node.RemainingSequence(); got != 1<<16-count {
+		t.Errorf("RemainingSequence = %d, want %d", got, 1<<16-count)
+	}
+
+	if _, err := node.Generate(IDType(16)); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType for type 16, got %v", err)
+	}
+}
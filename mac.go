@@ -0,0 +1,107 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+)
+
+// ErrNoHardwareAddr is returned by NodeIDFromMAC when no usable network interface is found.
+var ErrNoHardwareAddr = errors.New("arbiterid: no non-loopback interface with a hardware address")
+
+// netInterfaces lists the host's network interfaces and osHostname reads the host name;
+// replaced in tests.
+var (
+	netInterfaces = net.Interfaces
+	osHostname    = os.Hostname
+)
+
+// NodeIDFromMAC derives a node ID from the hardware address of the first non-loopback
+// network interface, so each physical host gets a stable ID without configuration.
+//
+// With only NodeMax+1 (4) node IDs, two hosts collide with probability 1/4 and a
+// collision among five or more hosts is certain. Colliding nodes produce duplicate IDs,
+// so check the resulting fleet assignment with ValidateNodeAssignment before relying on it.
+func NodeIDFromMAC() (int, error) {
+	mac, err := firstHardwareAddr()
+	if err != nil {
+		return 0, err
+	}
+	return int(fnv32a(mac) % uint32(NodeMax+1)), nil
+}
+
+// firstHardwareAddr returns the hardware address of the first non-loopback interface.
+func firstHardwareAddr() (net.HardwareAddr, error) {
+	ifaces, err := netInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("arbiterid: failed to list network interfaces: %w", err)
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		return iface.HardwareAddr, nil
+	}
+	return nil, ErrNoHardwareAddr
+}
+
+// fnv32a is the default host hash: 32-bit FNV-1a.
+func fnv32a(b []byte) uint32 {
+	h := fnv.New32a()
+	h.Write(b)
+	return h.Sum32()
+}
+
+// WithHostHash replaces the FNV-1a hash NewNodeFromHost applies to the hostname or
+// hardware address, e.g. to parse the ordinal out of a StatefulSet pod name ("svc-2")
+// so that pods get distinct node IDs. The result is reduced modulo the number of node
+// IDs. It has no effect on NewNode. A nil function is ignored.
+func WithHostHash(hash func(host []byte) uint32) NodeOption {
+	return func(n *Node) {
+		if hash != nil {
+			n.hostHash = hash
+		}
+	}
+}
+
+// NewNodeFromHost creates a Node whose ID is derived from the host, for stateless
+// deployments such as Kubernetes pods where assigning IDs by hand is impractical. It
+// hashes the hostname, or the first non-loopback hardware address if the hostname is
+// unavailable, modulo the number of node IDs in the layout (NodeMax+1 by default, more
+// with WithBitLayout).
+//
+// With only 4 node IDs in the default layout, two hosts collide with probability 1/4 and
+// five or more always collide; colliding nodes produce duplicate IDs. Use it only where
+// that risk is acceptable, widen the node field with WithBitLayout, or supply a hash that
+// maps hosts to distinct IDs with WithHostHash.
+func NewNodeFromHost(options ...NodeOption) (*Node, error) {
+	// Apply the options to a scratch node to learn the layout and hash they select
+	probe := &Node{layout: DefaultLayout, hostHash: fnv32a}
+	for _, option := range options {
+		option(probe)
+	}
+	if err := probe.layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	host, err := hostIdentity()
+	if err != nil {
+		return nil, err
+	}
+	nodeID := int(uint64(probe.hostHash(host)) % uint64(probe.layout.nodeMax()+1))
+	return NewNode(nodeID, options...)
+}
+
+// hostIdentity returns the hostname, falling back to the first hardware address.
+func hostIdentity() ([]byte, error) {
+	if name, err := osHostname(); err == nil && name != "" {
+		return []byte(name), nil
+	}
+	mac, err := firstHardwareAddr()
+	if err != nil {
+		return nil, err
+	}
+	return mac, nil
+}
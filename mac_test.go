@@ -0,0 +1,119 @@
+package arbiterid
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+// stubInterfaces replaces the interface source for the duration of the test.
+func stubInterfaces(t *testing.T, ifaces []net.Interface, err error) {
+	t.Helper()
+	orig := netInterfaces
+	netInterfaces = func() ([]net.Interface, error) { return ifaces, err }
+	t.Cleanup(func() { netInterfaces = orig })
+}
+
+func mustMAC(t *testing.T, s string) net.HardwareAddr {
+	t.Helper()
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		t.Fatalf("ParseMAC(%q) failed: %v", s, err)
+	}
+	return mac
+}
+
+func TestNodeIDFromMAC(t *testing.T) {
+	loopback := net.Interface{Name: "lo", Flags: net.FlagLoopback | net.FlagUp}
+	eth0 := net.Interface{Name: "eth0", Flags: net.FlagUp, HardwareAddr: mustMAC(t, "00:1a:2b:3c:4d:5e")}
+	eth1 := net.Interface{Name: "eth1", Flags: net.FlagUp, HardwareAddr: mustMAC(t, "00:1a:2b:3c:4d:5f")}
+
+	stubInterfaces(t, []net.Interface{loopback, eth0, eth1}, nil)
+	first, err := NodeIDFromMAC()
+	if err != nil {
+		t.Fatalf("NodeIDFromMAC failed: %v", err)
+	}
+	if first < 0 || int64(first) > NodeMax {
+		t.Fatalf("NodeIDFromMAC() = %d, outside 0..%d", first, NodeMax)
+	}
+	if again, _ := NodeIDFromMAC(); again != first {
+		t.Errorf("Derivation not stable: %d then %d", first, again)
+	}
+
+	// Only the first usable interface counts, regardless of what follows it
+	stubInterfaces(t, []net.Interface{eth0}, nil)
+	if got, _ := NodeIDFromMAC(); got != first {
+		t.Errorf("NodeIDFromMAC() = %d with eth0 alone, want %d", got, first)
+	}
+
+	stubInterfaces(t, []net.Interface{loopback, {Name: "tun0", Flags: net.FlagUp}}, nil)
+	if _, err := NodeIDFromMAC(); !errors.Is(err, ErrNoHardwareAddr) {
+		t.Errorf("Expected ErrNoHardwareAddr, got %v", err)
+	}
+
+	errList := errors.New("permission denied")
+	stubInterfaces(t, nil, errList)
+	if _, err := NodeIDFromMAC(); !errors.Is(err, errList) {
+		t.Errorf("Expected interface listing error, got %v", err)
+	}
+}
+
+// stubHostname replaces the hostname source for the duration of the test.
+func stubHostname(t *testing.T, name string, err error) {
+	t.Helper()
+	orig := osHostname
+	osHostname = func() (string, error) { return name, err }
+	t.Cleanup(func() { osHostname = orig })
+}
+
+func TestNewNodeFromHost(t *testing.T) {
+	eth0 := net.Interface{Name: "eth0", Flags: net.FlagUp, HardwareAddr: mustMAC(t, "00:1a:2b:3c:4d:5e")}
+	stubInterfaces(t, []net.Interface{eth0}, nil)
+
+	t.Run("Hostname", func(t *testing.T) {
+		stubHostname(t, "id-service-7d9f8", nil)
+		node, err := NewNodeFromHost(WithQuietMode(true))
+		if err != nil {
+			t.Fatalf("NewNodeFromHost failed: %v", err)
+		}
+		if want := int64(fnv32a([]byte("id-service-7d9f8")) % uint32(NodeMax+1)); node.node != want {
+			t.Errorf("Node ID = %d, want %d", node.node, want)
+		}
+	})
+
+	t.Run("FallsBackToMAC", func(t *testing.T) {
+		stubHostname(t, "", errors.New("no hostname"))
+		node, err := NewNodeFromHost(WithQuietMode(true))
+		if err != nil {
+			t.Fatalf("NewNodeFromHost failed: %v", err)
+		}
+		if want, _ := NodeIDFromMAC(); node.node != int64(want) {
+			t.Errorf("Node ID = %d, want the MAC-derived %d", node.node, want)
+		}
+
+		stubInterfaces(t, nil, nil)
+		if _, err := NewNodeFromHost(WithQuietMode(true)); !errors.Is(err, ErrNoHardwareAddr) {
+			t.Errorf("Expected ErrNoHardwareAddr, got %v", err)
+		}
+	})
+
+	t.Run("CustomHashAndLayout", func(t *testing.T) {
+		// A StatefulSet ordinal mapped directly to the node ID, with room for 64 nodes
+		stubHostname(t, "id-service-42", nil)
+		ordinal := func(host []byte) uint32 {
+			var n uint32
+			for _, c := range host[strings.LastIndexByte(string(host), '-')+1:] {
+				n = n*10 + uint32(c-'0')
+			}
+			return n
+		}
+		node, err := NewNodeFromHost(WithQuietMode(true), WithHostHash(ordinal), WithBitLayout(10, 6, 6))
+		if err != nil {
+			t.Fatalf("NewNodeFromHost failed: %v", err)
+		}
+		if node.node != 42 {
+			t.Errorf("Node ID = %d, want the ordinal 42", node.node)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package arbiterid
+
+import "container/heap"
+
+// Merger performs a k-way merge of sorted ID streams, such as per-node logs, into one
+// globally sorted stream. Each input must already be in ascending order.
+// A Merger is not safe for concurrent use.
+type Merger struct {
+	h mergeHeap
+}
+
+// mergeCursor is the read position within one input stream.
+type mergeCursor struct {
+	ids []ID
+	pos int
+}
+
+// mergeHeap orders cursors by their current ID.
+type mergeHeap []*mergeCursor
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i].ids[h[i].pos] < h[j].ids[h[j].pos] }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)        { *h = append(*h, x.(*mergeCursor)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// NewMerger returns a Merger over the given sorted streams. The slices are read but not modified.
+func NewMerger(streams ...[]ID) *Merger {
+	m := &Merger{h: make(mergeHeap, 0, len(streams))}
+	for _, ids := range streams {
+		if len(ids) > 0 {
+			m.h = append(m.h, &mergeCursor{ids: ids})
+		}
+	}
+	heap.Init(&m.h)
+	return m
+}
+
+// Next returns the smallest ID not yet returned across all streams, or false once every
+// stream is exhausted.
+func (m *Merger) Next() (ID, bool) {
+	if len(m.h) == 0 {
+		return 0, false
+	}
+	c := m.h[0]
+	id := c.ids[c.pos]
+	c.pos++
+	if c.pos == len(c.ids) {
+		heap.Pop(&m.h)
+	} else {
+		heap.Fix(&m.h, 0)
+	}
+	return id, true
+}
@@ -0,0 +1,46 @@
+package arbiterid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMerger(t *testing.T) {
+	start := time.Now().Add(-time.Minute)
+	var streams [][]ID
+	total := 0
+	for nodeID := 0; nodeID < 3; nodeID++ {
+		node := newTestNode(t, nodeID, WithQuietMode(true))
+		var ids []ID
+		// Interleave timestamps across nodes, with a different count per node
+		for i := 0; i < 10+nodeID*5; i++ {
+			id, err := node.GenerateWithTimestamp(testType1, start.Add(time.Duration(i*3+nodeID)*time.Millisecond))
+			if err != nil {
+				t.Fatalf("GenerateWithTimestamp failed: %v", err)
+			}
+			ids = append(ids, id)
+		}
+		streams = append(streams, ids)
+		total += len(ids)
+	}
+	streams = append(streams, nil) // Empty streams are allowed
+
+	m := NewMerger(streams...)
+	var merged []ID
+	for id, ok := m.Next(); ok; id, ok = m.Next() {
+		merged = append(merged, id)
+	}
+
+	if len(merged) != total {
+		t.Fatalf("Merged %d IDs, want %d", len(merged), total)
+	}
+	if _, err := VerifyMonotonic(merged); err != nil {
+		t.Error(err)
+	}
+	if _, ok := m.Next(); ok {
+		t.Error("Next should keep returning false once exhausted")
+	}
+	if _, ok := NewMerger().Next(); ok {
+		t.Error("Merger with no streams should be empty")
+	}
+}
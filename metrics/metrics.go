@@ -0,0 +1,75 @@
+// Package metrics exports an arbiterid Node's generation counters to Prometheus.
+//
+// It is a separate module so that the arbiterid package itself stays free of
+// dependencies; import it only in services that already use Prometheus:
+//
+//	node, err := arbiterid.NewNode(nodeID)
+//	...
+//	prometheus.MustRegister(metrics.NewCollector(node, prometheus.Labels{"node": strconv.Itoa(nodeID)}))
+package metrics
+
+import (
+	"github.com/githonllc/arbiterid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsProvider is anything that reports arbiterid generation counters, such as *arbiterid.Node.
+type StatsProvider interface {
+	Stats() arbiterid.Stats
+}
+
+// Collector is a prometheus.Collector reporting a StatsProvider's counters. Each scrape
+// takes one Stats snapshot, so the reported values are consistent with each other.
+//
+// Metrics, all prefixed "arbiterid_":
+//
+//   - ids_generated_total: IDs generated; use rate() for the generation rate
+//   - clock_backwards_total: significant backwards clock movements
+//   - sequence_rollovers_total: times a millisecond's sequences ran out
+//   - monotonicity_violations_total: generations rejected by the monotonicity check
+//   - last_timestamp_seconds: Unix time of the latest generation, zero before the first ID
+type Collector struct {
+	provider StatsProvider
+
+	generated      *prometheus.Desc
+	clockBackwards *prometheus.Desc
+	rollovers      *prometheus.Desc
+	violations     *prometheus.Desc
+	lastTimestamp  *prometheus.Desc
+}
+
+// NewCollector returns a Collector for provider. constLabels, which may be nil, are
+// attached to every metric; give each node a distinguishing label, such as its node ID,
+// when registering collectors for several nodes with the same registry.
+func NewCollector(provider StatsProvider, constLabels prometheus.Labels) *Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("arbiterid", "", name), help, nil, constLabels)
+	}
+	return &Collector{
+		provider:       provider,
+		generated:      desc("ids_generated_total", "Number of IDs generated."),
+		clockBackwards: desc("clock_backwards_total", "Number of significant backwards clock movements observed."),
+		rollovers:      desc("sequence_rollovers_total", "Number of times a millisecond's sequences ran out."),
+		violations:     desc("monotonicity_violations_total", "Number of generations rejected by the monotonicity check."),
+		lastTimestamp:  desc("last_timestamp_seconds", "Unix time of the latest generation, zero before the first ID."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.generated
+	ch <- c.clockBackwards
+	ch <- c.rollovers
+	ch <- c.violations
+	ch <- c.lastTimestamp
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	s := c.provider.Stats()
+	ch <- prometheus.MustNewConstMetric(c.generated, prometheus.CounterValue, float64(s.TotalGenerated))
+	ch <- prometheus.MustNewConstMetric(c.clockBackwards, prometheus.CounterValue, float64(s.ClockBackwardsEvents))
+	ch <- prometheus.MustNewConstMetric(c.rollovers, prometheus.CounterValue, float64(s.SequenceRollovers))
+	ch <- prometheus.MustNewConstMetric(c.violations, prometheus.CounterValue, float64(s.MonotonicityViolations))
+	ch <- prometheus.MustNewConstMetric(c.lastTimestamp, prometheus.GaugeValue, float64(s.LastTimestampMillis)/1000)
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/githonllc/arbiterid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type fixedStats arbiterid.Stats
+
+func (s fixedStats) Stats() arbiterid.Stats { return arbiterid.Stats(s) }
+
+func TestCollector(t *testing.T) {
+	stats := fixedStats{
+		TotalGenerated:         42,
+		ClockBackwardsEvents:   2,
+		SequenceRollovers:      3,
+		MonotonicityViolations: 1,
+		LastTimestampMillis:    1750000000500,
+	}
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(NewCollector(stats, prometheus.Labels{"node": "1"})); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	want := `
+# HELP arbiterid_clock_backwards_total Number of significant backwards clock movements observed.
+# TYPE arbiterid_clock_backwards_total counter
+arbiterid_clock_backwards_total{node="1"} 2
+# HELP arbiterid_ids_generated_total Number of IDs generated.
+# TYPE arbiterid_ids_generated_total counter
+arbiterid_ids_generated_total{node="1"} 42
+# HELP arbiterid_last_timestamp_seconds Unix time of the latest generation, zero before the first ID.
+# TYPE arbiterid_last_timestamp_seconds gauge
+arbiterid_last_timestamp_seconds{node="1"} 1.7500000005e+09
+# HELP arbiterid_monotonicity_violations_total Number of generations rejected by the monotonicity check.
+# TYPE arbiterid_monotonicity_violations_total counter
+arbiterid_monotonicity_violations_total{node="1"} 1
+# HELP arbiterid_sequence_rollovers_total Number of times a millisecond's sequences ran out.
+# TYPE arbiterid_sequence_rollovers_total counter
+arbiterid_sequence_rollovers_total{node="1"} 3
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollector_Node(t *testing.T) {
+	node, err := arbiterid.NewNode(0, arbiterid.WithQuietMode(true))
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
+	}
+	c := NewCollector(node, nil)
+	for i := 0; i < 5; i++ {
+		node.GenerateSimple(1)
+	}
+
+	if got := testutil.CollectAndCount(c); got != 5 {
+		t.Errorf("Expected 5 metrics, got %d", got)
+	}
+	want := "# HELP arbiterid_ids_generated_total Number of IDs generated.\n# TYPE arbiterid_ids_generated_total counter\narbiterid_ids_generated_total 5\n"
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "arbiterid_ids_generated_total"); err != nil {
+		t.Error(err)
+	}
+}
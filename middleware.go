@@ -0,0 +1,53 @@
+package arbiterid
+
+// GenerateFunc generates an ID of the given type, with the signature of Node.Generate.
+type GenerateFunc func(idType IDType) (ID, error)
+
+// WithGenerateMiddleware wraps Node.Generate (and so GenerateSimple) with mw, for
+// cross-cutting concerns such as logging, metrics, tracing, or rate limiting. mw receives
+// the next function in the chain and returns one that calls it, or returns early
+// instead, e.g. with an error. The option may be given several times; the first
+// middleware given is the outermost. A nil middleware is ignored.
+//
+// Middleware runs outside the node's mutex, so it may block without stalling other
+// callers' generation, but it must be safe for concurrent use.
+func WithGenerateMiddleware(mw func(next GenerateFunc) GenerateFunc) NodeOption {
+	return func(n *Node) {
+		if mw != nil {
+			n.middleware = append(n.middleware, mw)
+		}
+	}
+}
+
+// buildGenerateChain composes the node's middleware around generateCore.
+func (n *Node) buildGenerateChain() GenerateFunc {
+	return n.chainAround(n.generateCore)
+}
+
+// chainAround composes the node's middleware around core.
+func (n *Node) chainAround(core GenerateFunc) GenerateFunc {
+	next := core
+	for i := len(n.middleware) - 1; i >= 0; i-- {
+		next = n.middleware[i](next)
+	}
+	return next
+}
+
+// WithFailureInjector makes Generate call inject before generating and return any error
+// it reports instead of an ID, so tests can exercise error handling deterministically
+// rather than provoking real failures. It is installed as middleware, in the order given
+// relative to WithGenerateMiddleware. A nil function is ignored; there is no injection by
+// default.
+func WithFailureInjector(inject func(idType IDType) error) NodeOption {
+	if inject == nil {
+		return func(*Node) {}
+	}
+	return WithGenerateMiddleware(func(next GenerateFunc) GenerateFunc {
+		return func(idType IDType) (ID, error) {
+			if err := inject(idType); err != nil {
+				return 0, err
+			}
+			return next(idType)
+		}
+	})
+}
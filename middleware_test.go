@@ -0,0 +1,109 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithGenerateMiddleware(t *testing.T) {
+	var calls int
+	var order []string
+	counting := func(next GenerateFunc) GenerateFunc {
+		return func(idType IDType) (ID, error) {
+			calls++
+			order = append(order, "counting")
+			return next(idType)
+		}
+	}
+	tracing := func(next GenerateFunc) GenerateFunc {
+		return func(idType IDType) (ID, error) {
+			order = append(order, "tracing")
+			return next(idType)
+		}
+	}
+
+	node := newTestNode(t, testNodeID0, WithQuietMode(true),
+		WithGenerateMiddleware(counting), WithGenerateMiddleware(nil), WithGenerateMiddleware(tracing))
+
+	for i := 0; i < 3; i++ {
+		if _, err := node.Generate(testType1); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+	node.GenerateSimple(testType1)
+
+	if calls != 4 {
+		t.Errorf("Expected middleware to see 4 calls, got %d", calls)
+	}
+	if len(order) < 2 || order[0] != "counting" || order[1] != "tracing" {
+		t.Errorf("Expected the first middleware to run outermost, got order %v", order)
+	}
+	if got := node.GeneratedCount(); got != 4 {
+		t.Errorf("Expected 4 IDs generated, got %d", got)
+	}
+}
+
+func TestWithGenerateMiddleware_InjectError(t *testing.T) {
+	errRateLimited := errors.New("rate limited")
+	limit := 2
+	limiter := func(next GenerateFunc) GenerateFunc {
+		return func(idType IDType) (ID, error) {
+			if limit == 0 {
+				return 0, errRateLimited
+			}
+			limit--
+			return next(idType)
+		}
+	}
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithGenerateMiddleware(limiter))
+
+	for i := 0; i < 2; i++ {
+		if _, err := node.Generate(testType1); err != nil {
+			t.Fatalf("Generate %d failed: %v", i, err)
+		}
+	}
+	last := node.LastID()
+	if _, err := node.Generate(testType1); !errors.Is(err, errRateLimited) {
+		t.Errorf("Expected injected error, got %v", err)
+	}
+	if node.LastID() != last {
+		t.Error("Rejected call should not have generated an ID")
+	}
+}
+
+func TestWithFailureInjector(t *testing.T) {
+	errInjected := errors.New("injected failure")
+	calls := 0
+	everyThird := func(idType IDType) error {
+		calls++
+		if calls%3 == 0 {
+			return fmt.Errorf("type %d: %w", idType, errInjected)
+		}
+		return nil
+	}
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithFailureInjector(everyThird))
+
+	for i := 1; i <= 9; i++ {
+		last := node.LastID()
+		id, err := node.Generate(testType1)
+		if i%3 == 0 {
+			if !errors.Is(err, errInjected) {
+				t.Errorf("Call %d: expected injected error, got %v", i, err)
+			}
+			if node.LastID() != last {
+				t.Errorf("Call %d: failed call should not have generated an ID", i)
+			}
+			continue
+		}
+		if err != nil || id != node.LastID() {
+			t.Errorf("Call %d: Generate = %d, %v", i, id, err)
+		}
+	}
+
+	// Without an injector nothing fails
+	plain := newTestNode(t, testNodeID0, WithQuietMode(true), WithFailureInjector(nil))
+	if _, err := plain.Generate(testType1); err != nil {
+		t.Errorf("Generate without injector failed: %v", err)
+	}
+}
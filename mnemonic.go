@@ -0,0 +1,62 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	mnemonicWordBits  = 10
+	mnemonicWordCount = (63 + mnemonicWordBits - 1) / mnemonicWordBits // 7 words cover 63 bits
+	mnemonicSeparator = "-"
+)
+
+// ErrInvalidMnemonic is returned when a string cannot be decoded by ParseMnemonic.
+var ErrInvalidMnemonic = errors.New("arbiterid: invalid mnemonic")
+
+// mnemonicIndex maps each word of mnemonicWords back to its 10-bit value.
+var mnemonicIndex = func() map[string]int64 {
+	index := make(map[string]int64, len(mnemonicWords))
+	for i, w := range mnemonicWords {
+		index[w] = int64(i)
+	}
+	return index
+}()
+
+// Mnemonic returns the ID as seven hyphen-separated English words, each encoding 10 bits
+// (most significant first), e.g. "ability-animal-dragon-...". It is meant for reading IDs
+// aloud, such as over a support call. The first word only carries the top 3 bits.
+func (id ID) Mnemonic() string {
+	words := make([]string, mnemonicWordCount)
+	v := uint64(id)
+	for i := mnemonicWordCount - 1; i >= 0; i-- {
+		words[i] = mnemonicWords[v&(1<<mnemonicWordBits-1)]
+		v >>= mnemonicWordBits
+	}
+	return strings.Join(words, mnemonicSeparator)
+}
+
+// ParseMnemonic converts a string produced by Mnemonic to an ID.
+// Words may be separated by hyphens or whitespace and are matched case-insensitively.
+func ParseMnemonic(s string) (ID, error) {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == '-' || r == ' ' || r == '\t'
+	})
+	if len(words) != mnemonicWordCount {
+		return 0, fmt.Errorf("%w: '%s' has %d words, expected %d", ErrInvalidMnemonic, s, len(words), mnemonicWordCount)
+	}
+
+	var v int64
+	for i, w := range words {
+		idx, ok := mnemonicIndex[w]
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown word '%s' in '%s'", ErrInvalidMnemonic, w, s)
+		}
+		if i == 0 && idx>>(63-(mnemonicWordCount-1)*mnemonicWordBits) != 0 {
+			return 0, fmt.Errorf("%w: '%s' overflows 63 bits", ErrInvalidMnemonic, s)
+		}
+		v = v<<mnemonicWordBits | idx
+	}
+	return ID(v), nil
+}
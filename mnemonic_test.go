@@ -0,0 +1,80 @@
+package arbiterid
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMnemonicWords(t *testing.T) {
+	if len(mnemonicWords) != 1<<mnemonicWordBits {
+		t.Fatalf("Word list has %d words, want %d", len(mnemonicWords), 1<<mnemonicWordBits)
+	}
+	seen := make(map[string]bool, len(mnemonicWords))
+	for i, w := range mnemonicWords {
+		if seen[w] {
+			t.Errorf("Duplicate word %q at index %d", w, i)
+		}
+		seen[w] = true
+	}
+}
+
+func TestMnemonicWords_Denylist(t *testing.T) {
+	// The words are read aloud to customers, so none may be, or contain, an offensive word
+	denylist := []string{
+		"nig", "fag", "fuk", "fuck", "fap", "jiz", "dik", "dick", "kok", "cock", "tit", "pis",
+		"piss", "rap", "rape", "cum", "cunt", "shit", "slut", "whore", "ass", "bitch", "damn",
+		"hell", "sex", "porn", "nazi", "kike", "spic", "chink", "coon", "dyke", "homo", "retard",
+	}
+	for _, w := range mnemonicWords {
+		for _, bad := range denylist {
+			// Short entries only match whole words so innocent words like "assist" pass
+			if w == bad || len(bad) > 4 && strings.Contains(w, bad) {
+				t.Errorf("Word %q matches denylisted %q", w, bad)
+			}
+		}
+	}
+}
+
+func TestID_Mnemonic_ParseMnemonic(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ids := []ID{0, 1, 1023, 1024, node.GenerateSimple(testType0), node.GenerateSimple(testTypeMax), ID(math.MaxInt64)}
+
+	for _, id := range ids {
+		m := id.Mnemonic()
+		if n := len(strings.Split(m, mnemonicSeparator)); n != mnemonicWordCount {
+			t.Errorf("Mnemonic(%d) = %q has %d words, want %d", id, m, n, mnemonicWordCount)
+		}
+		parsed, err := ParseMnemonic(m)
+		if err != nil {
+			t.Errorf("ParseMnemonic(%q) failed: %v", m, err)
+			continue
+		}
+		if parsed != id {
+			t.Errorf("ParseMnemonic(%q) = %d, want %d", m, parsed, id)
+		}
+	}
+
+	// Whitespace separators and upper case are accepted
+	id := node.GenerateSimple(testTypeMax)
+	spoken := strings.ToUpper(strings.ReplaceAll(id.Mnemonic(), mnemonicSeparator, " "))
+	if parsed, err := ParseMnemonic(spoken); err != nil || parsed != id {
+		t.Errorf("ParseMnemonic(%q) = %d, %v; want %d", spoken, parsed, err, id)
+	}
+}
+
+func TestParseMnemonic_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"abandon-abandon-abandon", // too few words
+		"abandon-abandon-abandon-abandon-abandon-abandon-xyz", // unknown word
+		"ability-abandon-abandon-abandon-abandon-abandon-abandon-abandon",
+		"lend-abandon-abandon-abandon-abandon-abandon-abandon", // first word exceeds 3 bits
+	}
+	for _, input := range inputs {
+		if _, err := ParseMnemonic(input); !errors.Is(err, ErrInvalidMnemonic) {
+			t.Errorf("ParseMnemonic(%q) = %v, want ErrInvalidMnemonic", input, err)
+		}
+	}
+}
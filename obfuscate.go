@@ -0,0 +1,158 @@
+package arbiterid
+
+import (
+	"cmp"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Node obfuscation errors
+var (
+	ErrInvalidObfuscationKey = errors.New("arbiterid: obfuscation key must be 16, 24, or 32 bytes")
+	ErrNoObfuscationKey      = errors.New("arbiterid: node was not created with WithObfuscationKey")
+)
+
+// obfuscationRounds is the number of Feistel rounds; four rounds of a pseudorandom
+// function give a strong pseudorandom permutation (Luby-Rackoff).
+const obfuscationRounds = 4
+
+// ObfuscateID maps id to another non-negative ID with a keyed pseudorandom permutation,
+// hiding the creation time, node, and sequence in public-facing IDs while staying
+// reversible with DeobfuscateID and the same key. Distinct IDs always map to distinct
+// results. Obfuscated IDs do not sort in creation order; see CompareDeobfuscated.
+//
+// The permutation is a four-round Feistel network over 64 bits with AES-128 as the round
+// function, cycle-walked so that results stay within the 63 bits of a valid ID.
+// id must be non-negative. This hides structure from casual inspection; it is not a
+// substitute for access control.
+func ObfuscateID(id ID, key [16]byte) ID {
+	return obfuscateWith(newObfuscationCipher(key), id)
+}
+
+// DeobfuscateID reverses ObfuscateID with the same key.
+func DeobfuscateID(id ID, key [16]byte) ID {
+	return deobfuscateWith(newObfuscationCipher(key), id)
+}
+
+// CompareDeobfuscated compares two obfuscated IDs by their underlying IDs, and so by
+// creation order, returning -1, 0, or +1 like cmp.Compare. It lets holders of the key
+// sort public IDs chronologically.
+func CompareDeobfuscated(a, b ID, key [16]byte) int {
+	block := newObfuscationCipher(key)
+	return cmp.Compare(deobfuscateWith(block, a), deobfuscateWith(block, b))
+}
+
+// WithObfuscationKey sets the secret AES key Node.Obfuscate and Node.Deobfuscate use to
+// scramble public-facing IDs. It must be 16, 24, or 32 bytes (AES-128, -192, or -256),
+// otherwise NewNode returns ErrInvalidObfuscationKey; a 16-byte key gives the same mapping
+// as ObfuscateID. Every node that deobfuscates a value must share the key of the node that
+// produced it. The key is copied.
+func WithObfuscationKey(key []byte) NodeOption {
+	return func(n *Node) {
+		n.obfuscationKey = append([]byte(nil), key...)
+	}
+}
+
+// initObfuscation creates the cipher for a WithObfuscationKey key.
+func (n *Node) initObfuscation() error {
+	if n.obfuscationKey == nil {
+		return nil
+	}
+	block, err := aes.NewCipher(n.obfuscationKey)
+	if err != nil {
+		return fmt.Errorf("%w: got %d bytes", ErrInvalidObfuscationKey, len(n.obfuscationKey))
+	}
+	n.obfuscator = block
+	return nil
+}
+
+// Obfuscate scrambles id with the node's obfuscation key into a value suitable for URLs:
+// distinct IDs give distinct values that fit in 63 bits, but the values are neither
+// ordered nor reveal the ID's timestamp, node, or sequence. Deobfuscate reverses it. The
+// permutation is the one described at ObfuscateID, so it is a bijection over the
+// non-negative IDs; the sign bit of a negative id is ignored.
+//
+// It panics if the node was not created with WithObfuscationKey, which is a configuration
+// mistake rather than a runtime condition.
+func (n *Node) Obfuscate(id ID) uint64 {
+	if n.obfuscator == nil {
+		panic(ErrNoObfuscationKey)
+	}
+	return uint64(obfuscateWith(n.obfuscator, id&math.MaxInt64))
+}
+
+// Deobfuscate recovers the ID that Obfuscate turned into v. It returns ErrNoObfuscationKey
+// if the node has no key and ErrInvalidID if v has more than 63 bits, which Obfuscate
+// never produces. A value obfuscated under a different key decodes to an unrelated ID,
+// so callers should still check the result, e.g. with Validate.
+func (n *Node) Deobfuscate(v uint64) (ID, error) {
+	if n.obfuscator == nil {
+		return 0, ErrNoObfuscationKey
+	}
+	if v>>63 != 0 {
+		return 0, fmt.Errorf("%w: obfuscated value %d exceeds 63 bits", ErrInvalidID, v)
+	}
+	return deobfuscateWith(n.obfuscator, ID(v)), nil
+}
+
+// obfuscateWith applies the permutation, re-encrypting until the result fits in 63 bits.
+// Because the Feistel network permutes all 64-bit values, this cycle walk permutes the
+// 63-bit ones.
+func obfuscateWith(block cipher.Block, id ID) ID {
+	v := uint64(id)
+	for {
+		v = feistelEncrypt(block, v)
+		if v>>63 == 0 {
+			return ID(v)
+		}
+	}
+}
+
+// deobfuscateWith inverts obfuscateWith by walking the cycle backwards.
+func deobfuscateWith(block cipher.Block, id ID) ID {
+	v := uint64(id)
+	for {
+		v = feistelDecrypt(block, v)
+		if v>>63 == 0 {
+			return ID(v)
+		}
+	}
+}
+
+func newObfuscationCipher(key [16]byte) cipher.Block {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		// A 16-byte key is always a valid AES-128 key
+		panic("arbiterid: " + err.Error())
+	}
+	return block
+}
+
+// feistelRound is the round function: AES of the round number and half, truncated to 32 bits.
+func feistelRound(block cipher.Block, round int, half uint32) uint32 {
+	var in, out [aes.BlockSize]byte
+	in[0] = byte(round)
+	binary.BigEndian.PutUint32(in[1:], half)
+	block.Encrypt(out[:], in[:])
+	return binary.BigEndian.Uint32(out[:])
+}
+
+func feistelEncrypt(block cipher.Block, v uint64) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := 0; i < obfuscationRounds; i++ {
+		l, r = r, l^feistelRound(block, i, r)
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+func feistelDecrypt(block cipher.Block, v uint64) uint64 {
+	l, r := uint32(v>>32), uint32(v)
+	for i := obfuscationRounds - 1; i >= 0; i-- {
+		l, r = r^feistelRound(block, i, l), l
+	}
+	return uint64(l)<<32 | uint64(r)
+}
@@ -0,0 +1,126 @@
+package arbiterid
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+)
+
+var testObfuscationKey = [16]byte{0x2b, 0x7e, 0x15, 0x16, 0x28, 0xae, 0xd2, 0xa6, 0xab, 0xf7, 0x15, 0x88, 0x09, 0xcf, 0x4f, 0x3c}
+
+func TestObfuscateID_RoundTrip(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ids := []ID{0, 1, 2, node.GenerateSimple(testType1), ID(math.MaxInt64)}
+
+	seen := make(map[ID]bool)
+	for _, id := range ids {
+		obf := ObfuscateID(id, testObfuscationKey)
+		if obf < 0 {
+			t.Errorf("ObfuscateID(%d) = %d, want a non-negative ID", id, obf)
+		}
+		if seen[obf] {
+			t.Errorf("ObfuscateID(%d) = %d collides with another ID", id, obf)
+		}
+		seen[obf] = true
+		if got := DeobfuscateID(obf, testObfuscationKey); got != id {
+			t.Errorf("DeobfuscateID(ObfuscateID(%d)) = %d", id, got)
+		}
+	}
+
+	otherKey := testObfuscationKey
+	otherKey[0] ^= 1
+	if id := ids[3]; ObfuscateID(id, otherKey) == ObfuscateID(id, testObfuscationKey) {
+		t.Error("Different keys should give different obfuscated IDs")
+	}
+}
+
+func TestCompareDeobfuscated(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	ids, err := node.GenerateBatch(testType1, 50)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+
+	public := make([]ID, len(ids))
+	for i, id := range ids {
+		public[i] = ObfuscateID(id, testObfuscationKey)
+	}
+	if _, err := VerifyMonotonic(public); err == nil {
+		t.Error("Obfuscated IDs should not preserve numeric order")
+	}
+
+	sort.Slice(public, func(i, j int) bool {
+		return CompareDeobfuscated(public[i], public[j], testObfuscationKey) < 0
+	})
+	for i, obf := range public {
+		if got := DeobfuscateID(obf, testObfuscationKey); got != ids[i] {
+			t.Fatalf("Position %d: sorted obfuscated ID decodes to %d, want %d", i, got, ids[i])
+		}
+	}
+
+	if CompareDeobfuscated(public[0], public[0], testObfuscationKey) != 0 {
+		t.Error("An ID should compare equal to itself")
+	}
+}
+
+func TestNode_Obfuscate(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithObfuscationKey(testObfuscationKey[:]))
+	ids, err := node.GenerateBatch(testType1, 1000)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	ids = append(ids, 0, 1, ID(math.MaxInt64))
+
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		v := node.Obfuscate(id)
+		if v>>63 != 0 {
+			t.Errorf("Obfuscate(%d) = %d exceeds 63 bits", id, v)
+		}
+		if seen[v] {
+			t.Errorf("Obfuscate(%d) = %d collides with another ID", id, v)
+		}
+		seen[v] = true
+		if got, err := node.Deobfuscate(v); err != nil || got != id {
+			t.Errorf("Deobfuscate(Obfuscate(%d)) = %d, %v", id, got, err)
+		}
+		if ID(v) != ObfuscateID(id, testObfuscationKey) {
+			t.Errorf("Obfuscate(%d) differs from ObfuscateID with the same 16-byte key", id)
+		}
+	}
+	if sort.SliceIsSorted(ids[:1000], func(i, j int) bool { return node.Obfuscate(ids[i]) < node.Obfuscate(ids[j]) }) {
+		t.Error("Obfuscated values should not preserve generation order")
+	}
+
+	otherKey := make([]byte, 32)
+	copy(otherKey, testObfuscationKey[:])
+	other := newTestNode(t, testNodeID0, WithQuietMode(true), WithObfuscationKey(otherKey))
+	same := 0
+	for _, id := range ids {
+		if other.Obfuscate(id) == node.Obfuscate(id) {
+			same++
+		}
+	}
+	if same != 0 {
+		t.Errorf("Different keys gave the same obfuscated value for %d IDs", same)
+	}
+
+	if _, err := node.Deobfuscate(1 << 63); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("Expected ErrInvalidID for a 64-bit value, got %v", err)
+	}
+	if _, err := NewNode(0, WithQuietMode(true), WithObfuscationKey([]byte("short"))); !errors.Is(err, ErrInvalidObfuscationKey) {
+		t.Errorf("Expected ErrInvalidObfuscationKey, got %v", err)
+	}
+
+	plain := newTestNode(t, testNodeID0, WithQuietMode(true))
+	if _, err := plain.Deobfuscate(1); !errors.Is(err, ErrNoObfuscationKey) {
+		t.Errorf("Expected ErrNoObfuscationKey, got %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Obfuscate to panic without a key")
+		}
+	}()
+	plain.Obfuscate(1)
+}
@@ -0,0 +1,319 @@
+package arbiterid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// EncodingKind identifies one of the string encodings supported by ID.
+type EncodingKind int
+
+// Supported encodings
+const (
+	EncodingDecimal EncodingKind = iota // ID.String / ParseString
+	EncodingBase2                       // ID.Base2 / ParseBase2
+	EncodingBase32                      // ID.Base32 / ParseBase32
+	EncodingBase58                      // ID.Base58 / ParseBase58
+	EncodingBase64                      // ID.Base64 / ParseBase64
+)
+
+// Encoding detection errors
+var (
+	ErrUnknownEncoding   = errors.New("arbiterid: unknown encoding")
+	ErrAmbiguousEncoding = errors.New("arbiterid: input matches more than one encoding")
+)
+
+// Fixed string lengths used to recognize encodings by shape
+const (
+	base2Len     = 63 // ID.Base2 always pads to 63 digits
+	base64Len    = 11 // 8 bytes in unpadded base64
+	maxBase58Len = 11
+	maxBase32Len = 13
+	maxDecimal   = 19 // Digits in math.MaxInt64
+)
+
+// base64URLAlphabet is the alphabet of base64.RawURLEncoding used by ID.Base64
+const base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// String returns the name of the encoding
+func (k EncodingKind) String() string {
+	switch k {
+	case EncodingDecimal:
+		return "decimal"
+	case EncodingBase2:
+		return "base2"
+	case EncodingBase32:
+		return "base32"
+	case EncodingBase58:
+		return "base58"
+	case EncodingBase64:
+		return "base64"
+	default:
+		return fmt.Sprintf("EncodingKind(%d)", int(k))
+	}
+}
+
+// valid reports whether k is one of the supported encodings.
+func (k EncodingKind) valid() bool {
+	return k >= EncodingDecimal && k <= EncodingBase64
+}
+
+// Parse decodes s using the given encoding.
+func Parse(s string, encoding EncodingKind) (ID, error) {
+	switch encoding {
+	case EncodingDecimal:
+		return ParseString(s)
+	case EncodingBase2:
+		return ParseBase2(s)
+	case EncodingBase32:
+		return ParseBase32(s)
+	case EncodingBase58:
+		return ParseBase58(s)
+	case EncodingBase64:
+		return ParseBase64(s)
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownEncoding, encoding)
+	}
+}
+
+// ParseAndValidate decodes s using the given encoding and rejects results that fail IsValid.
+// The returned error describes either failure and is suitable for a 400 response.
+func ParseAndValidate(s string, encoding EncodingKind) (ID, error) {
+	id, err := Parse(s, encoding)
+	if err != nil {
+		return 0, err
+	}
+	if err := id.Validate(); err != nil {
+		return 0, fmt.Errorf("%s value '%s' is not a plausible ID: %w", encoding, s, err)
+	}
+	return id, nil
+}
+
+// ParseOrZero decodes a decimal string like ParseString but returns the zero ID instead of
+// an error when s cannot be parsed, for best-effort bulk parsing. The zero ID is never
+// valid, and a string that parses may still not be a plausible ID, so callers should
+// check the result with IsValid.
+func ParseOrZero(s string) ID {
+	id, err := ParseString(s)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// byLengthOrder is the order in which ParseByLength prefers encodings that decode the same
+// string
+var byLengthOrder = []EncodingKind{EncodingBase2, EncodingDecimal, EncodingBase32, EncodingBase64, EncodingBase58}
+
+// ParseByLength decodes s after picking its encoding from its length and character set,
+// without being told the encoding. Candidates are:
+//
+//   - base2: exactly 63 characters of 0 and 1
+//   - decimal: 1-19 digits
+//   - base32: 1-13 characters of the z-base-32 alphabet
+//   - base64: exactly 11 characters of the URL-safe base64 alphabet
+//   - base58: 1-11 characters of the base58 alphabet
+//
+// The alphabets overlap (z-base-32 is a subset of base58, which is a subset of base64), so
+// a string often decodes under several of them. ParseByLength then picks deterministically:
+// it prefers a decoding that re-encodes to s, as the encoder's own output does, and among
+// those takes the first in the order listed above. If no decoding re-encodes to s (for
+// example base32 with leading zero digits), the first one that decodes at all is used.
+//
+// The order favours the encoder outputs most likely to be mistaken for another: Base32
+// output always fits the base58 and usually the base64 alphabet, while Base64 or Base58
+// output rarely fits the z-base-32 one. A base58 string that is entirely digits or
+// z-base-32 characters, or an 11-character one that is also canonical base64, is still
+// read the other way; use Parse with an explicit encoding for values that may be.
+func ParseByLength(s string) (ID, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: input string is empty", ErrUnknownEncoding)
+	}
+	var (
+		fallback ID
+		decoded  bool
+	)
+	for _, encoding := range byLengthOrder {
+		if !fitsShape(s, encoding) {
+			continue
+		}
+		id, err := Parse(s, encoding)
+		if err != nil {
+			continue
+		}
+		if encode(id, encoding) == s {
+			return id, nil
+		}
+		if !decoded {
+			fallback, decoded = id, true
+		}
+	}
+	if !decoded {
+		return 0, fmt.Errorf("%w: '%s' matches no encoding by length and character set", ErrUnknownEncoding, s)
+	}
+	return fallback, nil
+}
+
+// fitsShape reports whether s has the length and character set of the encoding.
+func fitsShape(s string, encoding EncodingKind) bool {
+	switch encoding {
+	case EncodingBase2:
+		return len(s) == base2Len && strings.Trim(s, "01") == ""
+	case EncodingDecimal:
+		return len(s) <= maxDecimal && strings.Trim(s, "0123456789") == ""
+	case EncodingBase32:
+		return len(s) <= maxBase32Len && inAlphabet(s, &decodeBase32Map)
+	case EncodingBase58:
+		return len(s) <= maxBase58Len && inAlphabet(s, &decodeBase58Map)
+	case EncodingBase64:
+		return len(s) == base64Len && strings.Trim(s, base64URLAlphabet) == ""
+	default:
+		return false
+	}
+}
+
+// encode formats id using the given encoding, the inverse of Parse.
+func encode(id ID, encoding EncodingKind) string {
+	switch encoding {
+	case EncodingDecimal:
+		return id.String()
+	case EncodingBase2:
+		return id.Base2()
+	case EncodingBase32:
+		return id.Base32()
+	case EncodingBase58:
+		return id.Base58()
+	case EncodingBase64:
+		return id.Base64()
+	default:
+		return ""
+	}
+}
+
+// parseAnyOrder is the order in which ParseAny tries encodings
+var parseAnyOrder = []EncodingKind{EncodingDecimal, EncodingBase2, EncodingBase64, EncodingBase58, EncodingBase32}
+
+// ParseAny decodes s without being told its encoding, for APIs whose clients send IDs in
+// different forms. It tries decimal, base2, base64, base58, and base32 in that order and
+// returns the first result that is a positive ID. If none is, the error wraps
+// ErrUnknownEncoding and lists each attempt.
+//
+// The order resolves ambiguous input as follows:
+//
+//   - all-digit strings of up to 19 digits are decimal, although most are also valid
+//     base58 (and base32, which shares some digits)
+//   - 63 characters of 0 and 1 are base2, as they overflow decimal
+//   - 11-character strings that decode as both base64 and base58 are whichever of the two
+//     re-encodes to s; if both do, the error wraps ErrAmbiguousEncoding
+//   - anything else is base64 or base58 if it decodes as such, otherwise base32
+//
+// Clients whose values could fall into the first or third case should send a fixed
+// encoding, decoded with Parse.
+func ParseAny(s string) (ID, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: input string is empty", ErrUnknownEncoding)
+	}
+	errs := make([]error, 0, len(parseAnyOrder))
+	for _, encoding := range parseAnyOrder {
+		id, err := Parse(s, encoding)
+		if err == nil && id <= 0 {
+			err = fmt.Errorf("%w: %d is not a positive ID", ErrInvalidID, id)
+		}
+		if err == nil && encoding == EncodingBase64 {
+			return resolveBase64(s, id)
+		}
+		if err == nil {
+			return id, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", encoding, err))
+	}
+	return 0, fmt.Errorf("%w: '%s' does not parse as any encoding: %w", ErrUnknownEncoding, s, errors.Join(errs...))
+}
+
+// resolveBase64 settles a string ParseAny decoded as base64 to id, which may also be valid
+// base58 (the base58 alphabet is a subset of base64's). Only an encoder's own output
+// re-encodes to the same string, so the decoding that round-trips wins.
+func resolveBase64(s string, id ID) (ID, error) {
+	alt, err := ParseBase58(s)
+	if err != nil || alt <= 0 {
+		return id, nil
+	}
+	isBase64, isBase58 := id.Base64() == s, alt.Base58() == s
+	switch {
+	case isBase64 && !isBase58:
+		return id, nil
+	case isBase58 && !isBase64:
+		return alt, nil
+	default:
+		return 0, fmt.Errorf("%w: '%s' decodes as base64 (%d) and base58 (%d)", ErrAmbiguousEncoding, s, id, alt)
+	}
+}
+
+// inAlphabet reports whether every byte of s has an entry in the decode map.
+func inAlphabet(s string, decodeMap *[256]byte) bool {
+	for i := 0; i < len(s); i++ {
+		if decodeMap[s[i]] == 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseColumn decodes every value of a column, such as one read from a CSV import, using
+// the given encoding. Surrounding whitespace is ignored. Rather than stopping at the first
+// bad row, it returns one ID per value, zero for rows that failed, along with the indexes
+// of the failed rows in ascending order. The error is non-nil only when the encoding
+// itself is unknown.
+func ParseColumn(values []string, encoding EncodingKind) ([]ID, []int, error) {
+	if !encoding.valid() {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownEncoding, encoding)
+	}
+
+	ids := make([]ID, len(values))
+	var failed []int
+	for i, v := range values {
+		id, err := Parse(strings.TrimSpace(v), encoding)
+		if err != nil {
+			failed = append(failed, i)
+			continue
+		}
+		ids[i] = id
+	}
+	return ids, failed, nil
+}
+
+// ParseStream decodes one ID per line of r using the given encoding, reading lazily so a
+// large file is never held in memory. Surrounding whitespace is ignored and blank lines
+// are skipped. A line that fails to parse yields a zero ID with an error naming its line
+// number, and the stream carries on with the next line. An unknown encoding or a read
+// error is yielded once and ends the stream. Breaking out of the loop stops reading.
+func ParseStream(r io.Reader, encoding EncodingKind) iter.Seq2[ID, error] {
+	return func(yield func(ID, error) bool) {
+		if !encoding.valid() {
+			yield(0, fmt.Errorf("%w: %s", ErrUnknownEncoding, encoding))
+			return
+		}
+
+		scanner := bufio.NewScanner(r)
+		for line := 1; scanner.Scan(); line++ {
+			s := strings.TrimSpace(scanner.Text())
+			if s == "" {
+				continue
+			}
+			id, err := Parse(s, encoding)
+			if err != nil {
+				id, err = 0, fmt.Errorf("line %d: %w", line, err)
+			}
+			if !yield(id, err) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(0, err)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_AllEncodings(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	id := node.GenerateSimple(testType1)
+
+	tests := []struct {
+		encoding EncodingKind
+		input    string
+	}{
+		{EncodingDecimal, id.String()},
+		{EncodingBase2, id.Base2()},
+		{EncodingBase32, id.Base32()},
+		{EncodingBase58, id.Base58()},
+		{EncodingBase64, id.Base64()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding.String(), func(t *testing.T) {
+			got, err := Parse(tt.input, tt.encoding)
+			if err != nil {
+				t.Fatalf("Parse(%q, %s) failed: %v", tt.input, tt.encoding, err)
+			}
+			if got != id {
+				t.Errorf("Parse(%q, %s) = %d, want %d", tt.input, tt.encoding, got, id)
+			}
+		})
+	}
+
+	if _, err := Parse(id.String(), EncodingKind(99)); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("Expected ErrUnknownEncoding, got %v", err)
+	}
+}
+
+func TestParseAndValidate(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	id := node.GenerateSimple(testType1)
+
+	t.Run("Valid", func(t *testing.T) {
+		got, err := ParseAndValidate(id.Base58(), EncodingBase58)
+		if err != nil {
+			t.Fatalf("ParseAndValidate failed: %v", err)
+		}
+		if got != id {
+			t.Errorf("Expected %d, got %d", id, got)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := ParseAndValidate("not-base58!", EncodingBase58)
+		if !errors.Is(err, ErrInvalidBase58) {
+			t.Errorf("Expected ErrInvalidBase58, got %v", err)
+		}
+	})
+
+	t.Run("Implausible", func(t *testing.T) {
+		// Structurally decodable, but timestamped decades in the future
+		future := time.Now().UTC().AddDate(30, 0, 0)
+		far, err := newTestNode(t, testNodeID0, WithQuietMode(true)).GenerateWithTimestamp(testType1, future)
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		if _, err := ParseAndValidate(far.Base58(), EncodingBase58); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Expected ErrInvalidID for future-dated ID, got %v", err)
+		}
+		if _, err := ParseAndValidate("0", EncodingDecimal); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Expected ErrInvalidID for zero ID, got %v", err)
+		}
+	})
+}
+
+func TestParseOrZero(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	id := node.GenerateSimple(testType1)
+
+	if got := ParseOrZero(id.String()); got != id || !got.IsValid() {
+		t.Errorf("ParseOrZero(%q) = %d, want valid %d", id.String(), got, id)
+	}
+	for _, bad := range []string{"", "abc", id.Base58(), "12 34", "9223372036854775808"} {
+		if got := ParseOrZero(bad); got != 0 || got.IsValid() {
+			t.Errorf("ParseOrZero(%q) = %d, want 0", bad, got)
+		}
+	}
+}
+
+func TestParseByLength(t *testing.T) {
+	// A type-0 ID has a short base58 form that cannot be mistaken for base64
+	small := ID(0x0000_1234_5678_9ABC)
+	large := ID(1234567890123456789)
+
+	tests := []struct {
+		name  string
+		input string
+		want  ID
+	}{
+		{"Base2", small.Base2(), small},
+		{"Decimal", large.String(), large},
+		{"Base32", large.Base32(), large},
+		{"Base58", small.Base58(), small},
+		{"Base64", small.Base64(), small},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseByLength(tt.input)
+			if err != nil {
+				t.Fatalf("ParseByLength(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseByLength(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("EncoderOutputs", func(t *testing.T) {
+		// Base32 output always fits the base58 alphabet and often base64's as well
+		for _, typ := range []IDType{testType0, testType1, testTypeMax} {
+			node := newTestNode(t, testNodeID0, WithQuietMode(true))
+			for range 100 {
+				id := node.GenerateSimple(typ)
+				for _, encoding := range byLengthOrder {
+					s := encode(id, encoding)
+					if got, err := ParseByLength(s); err != nil || got != id {
+						t.Fatalf("ParseByLength(%q) of %s output = %d, %v; want %d", s, encoding, got, err, id)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("Preference", func(t *testing.T) {
+		tests := []struct {
+			input string
+			want  EncodingKind
+		}{
+			{"123456789", EncodingDecimal},  // Also base58
+			{"g1efitcbyy", EncodingBase32},  // Also base58 that round-trips
+			{"eg1efitcbyb", EncodingBase32}, // Also base58 and base64
+			{"bGURAjY9ejs", EncodingBase64}, // Also base58; both round-trip
+			{"bGURAjY9ejt", EncodingBase58}, // Also base64, which does not round-trip
+			{"yyyyyyyyyyb", EncodingBase32}, // Leading zero digits, so only the fallback decodes
+		}
+		for _, tt := range tests {
+			want, err := Parse(tt.input, tt.want)
+			if err != nil {
+				t.Fatalf("Parse(%q, %s) failed: %v", tt.input, tt.want, err)
+			}
+			if got, err := ParseByLength(tt.input); err != nil || got != want {
+				t.Errorf("ParseByLength(%q) = %d, %v; want %s %d", tt.input, got, err, tt.want, want)
+			}
+		}
+	})
+
+	t.Run("Unrecognized", func(t *testing.T) {
+		for _, input := range []string{"", "not an id", "0OIl0OIl0OIl0OIl0OIl"} {
+			if _, err := ParseByLength(input); !errors.Is(err, ErrUnknownEncoding) {
+				t.Errorf("ParseByLength(%q): expected ErrUnknownEncoding, got %v", input, err)
+			}
+		}
+	})
+}
+
+func TestParseAny(t *testing.T) {
+	small := ID(0x0000_1234_5678_9ABC)
+	large := ID(1234567890123456789)
+
+	tests := []struct {
+		name  string
+		input string
+		want  ID
+	}{
+		{"Decimal", large.String(), large},
+		{"Base2", small.Base2(), small},
+		{"Base64", large.Base64(), large},
+		{"Base58", small.Base58(), small},
+		{"Base32", large.Base32(), large},
+		// Also valid base58, but digits resolve to decimal
+		{"AmbiguousDigits", "123456789", 123456789},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAny(tt.input)
+			if err != nil {
+				t.Fatalf("ParseAny(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAny(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Base58AlsoBase64", func(t *testing.T) {
+		// A type-512 ID's base58 form is 11 characters and decodes as base64 too, but
+		// only as base58 does it re-encode to the same string
+		id := ID(4611917196838700035)
+		if _, err := ParseBase64(id.Base58()); err != nil {
+			t.Fatalf("Test input %q should also be valid base64: %v", id.Base58(), err)
+		}
+		if got, err := ParseAny(id.Base58()); err != nil || got != id {
+			t.Errorf("ParseAny(%q) = %d, %v; want %d", id.Base58(), got, err, id)
+		}
+
+		// One ID earlier, both decodings round-trip
+		if _, err := ParseAny(ID(4611917196838700034).Base58()); !errors.Is(err, ErrAmbiguousEncoding) {
+			t.Errorf("Expected ErrAmbiguousEncoding, got %v", err)
+		}
+	})
+
+	t.Run("Unparseable", func(t *testing.T) {
+		for _, input := range []string{"", "not an id!", "-42"} {
+			_, err := ParseAny(input)
+			if !errors.Is(err, ErrUnknownEncoding) {
+				t.Errorf("ParseAny(%q): expected ErrUnknownEncoding, got %v", input, err)
+			}
+		}
+		// The error carries every attempt's failure
+		_, err := ParseAny("not an id!")
+		for _, want := range []error{ErrInvalidBase58, ErrInvalidBase32} {
+			if !errors.Is(err, want) {
+				t.Errorf("Error %q does not wrap %v", err, want)
+			}
+		}
+	})
+}
+
+func TestParseColumn(t *testing.T) {
+	a, b := ID(1234567890123), idForEncodingTests
+	column := []string{
+		a.Base58(),
+		"not-base58!",
+		"  " + b.Base58() + " ",
+		"",
+		ID(math.MaxInt64).Base58(),
+		"0OIl",
+	}
+
+	ids, failed, err := ParseColumn(column, EncodingBase58)
+	if err != nil {
+		t.Fatalf("ParseColumn failed: %v", err)
+	}
+	if len(ids) != len(column) {
+		t.Fatalf("Expected %d IDs, got %d", len(column), len(ids))
+	}
+	want := []ID{a, 0, b, 0, ID(math.MaxInt64), 0}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("Row %d: got %d, want %d", i, ids[i], want[i])
+		}
+	}
+	if fmt.Sprint(failed) != "[1 3 5]" {
+		t.Errorf("Failed rows = %v, want [1 3 5]", failed)
+	}
+
+	if _, failed, _ := ParseColumn([]string{"1", "2"}, EncodingDecimal); failed != nil {
+		t.Errorf("Expected no failed rows, got %v", failed)
+	}
+	if _, _, err := ParseColumn(column, EncodingKind(99)); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("Expected ErrUnknownEncoding, got %v", err)
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	a, b := ID(1234567890123), idForEncodingTests
+	input := a.Base58() + "\n" +
+		"not-base58!\n" +
+		"\n" +
+		"  " + b.Base58() + "  \r\n" +
+		ID(math.MaxInt64).Base58()
+
+	var (
+		ids  []ID
+		errs []error
+	)
+	for id, err := range ParseStream(strings.NewReader(input), EncodingBase58) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if fmt.Sprint(ids) != fmt.Sprint([]ID{a, b, ID(math.MaxInt64)}) {
+		t.Errorf("Parsed IDs = %v, want %v", ids, []ID{a, b, ID(math.MaxInt64)})
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrInvalidBase58) || !strings.Contains(errs[0].Error(), "line 2") {
+		t.Errorf("Expected one ErrInvalidBase58 error for line 2, got %v", errs)
+	}
+
+	t.Run("Break", func(t *testing.T) {
+		count := 0
+		for range ParseStream(strings.NewReader("1\n2\n3\n"), EncodingDecimal) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("Expected the stream to stop after 2 IDs, got %d", count)
+		}
+	})
+
+	t.Run("Unknown encoding", func(t *testing.T) {
+		count := 0
+		for _, err := range ParseStream(strings.NewReader("1\n2\n"), EncodingKind(99)) {
+			count++
+			if !errors.Is(err, ErrUnknownEncoding) {
+				t.Errorf("Expected ErrUnknownEncoding, got %v", err)
+			}
+		}
+		if count != 1 {
+			t.Errorf("Expected a single error, got %d results", count)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package arbiterid
+
+import "sync/atomic"
+
+// Pool spreads generation across several nodes with distinct node IDs in one process, so
+// it can issue up to SeqMax+1 IDs per millisecond for each node instead of one node's
+// worth. IDs from all nodes are unique, because their node bits differ, and sort by
+// timestamp like any other IDs of the same type; within a millisecond they are ordered by
+// node and sequence rather than by call order.
+type Pool struct {
+	nodes []*Node
+	next  atomic.Uint64
+}
+
+// NewPool creates one node per ID in nodeIDs, all configured with options, and returns a
+// Pool over them. nodeIDs must be non-empty and distinct, otherwise NewPool returns
+// ErrEmptyCluster or ErrDuplicateClusterID; an ID outside the layout's node range yields
+// ErrInvalidNodeID, as from NewNode. With the default layout the pool can hold at most
+// NodeMax+1 nodes.
+func NewPool(nodeIDs []int, options ...NodeOption) (*Pool, error) {
+	nodes := make([]*Node, 0, len(nodeIDs))
+	for _, id := range nodeIDs {
+		n, err := NewNode(id, options...)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	// NewCluster performs the emptiness and duplicate checks
+	if _, err := NewCluster(nodes...); err != nil {
+		return nil, err
+	}
+	return &Pool{nodes: nodes}, nil
+}
+
+// Generate creates a new ID on the pool's nodes in round-robin order. It is safe for
+// concurrent use.
+func (p *Pool) Generate(idType IDType) (ID, error) {
+	i := (p.next.Add(1) - 1) % uint64(len(p.nodes))
+	return p.nodes[i].Generate(idType)
+}
+
+// Nodes returns the pool's nodes in round-robin order.
+func (p *Pool) Nodes() []*Node {
+	return append([]*Node(nil), p.nodes...)
+}
@@ -0,0 +1,95 @@
+package arbiterid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool_Generate(t *testing.T) {
+	// With the clock pinned, one node can issue only SeqMax+1 IDs; four nodes issue four times that
+	fixed := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	pool, err := NewPool([]int{0, 1, 2, 3}, WithQuietMode(true), WithNowFunc(func() time.Time { return fixed }))
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+
+	const count = int(4 * (SeqMax + 1))
+	var (
+		mu  sync.Mutex
+		ids = make([]ID, 0, count)
+		wg  sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count/8; i++ {
+				id, err := pool.Generate(testType1)
+				if err != nil {
+					t.Errorf("Generate failed: %v", err)
+					return
+				}
+				mu.Lock()
+				ids = append(ids, id)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != count {
+		t.Fatalf("Expected %d IDs, got %d", count, len(ids))
+	}
+	perNode := make(map[int64]int)
+	seen := make(map[ID]bool, count)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("Duplicate ID %d", id)
+		}
+		seen[id] = true
+		perNode[id.Node()]++
+		if id.Time() != fixed.UnixMilli() {
+			t.Errorf("ID %d has timestamp %d, want %d", id, id.Time(), fixed.UnixMilli())
+		}
+	}
+	for node := int64(0); node <= 3; node++ {
+		if perNode[node] != int(SeqMax+1) {
+			t.Errorf("Node %d issued %d IDs, want %d", node, perNode[node], SeqMax+1)
+		}
+	}
+}
+
+func TestPool_Sortable(t *testing.T) {
+	pool, err := NewPool([]int{testNodeID0, testNodeID1}, WithQuietMode(true))
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+	first, _ := pool.Generate(testType1)
+	time.Sleep(2 * time.Millisecond)
+	second, _ := pool.Generate(testType1)
+	if first.Node() == second.Node() {
+		t.Errorf("Expected round-robin across nodes, both IDs came from node %d", first.Node())
+	}
+	if second <= first {
+		t.Errorf("ID %d from a later millisecond should sort after %d", second, first)
+	}
+	if got := pool.Nodes(); len(got) != 2 || got[0].node != testNodeID0 {
+		t.Errorf("Unexpected pool nodes %v", got)
+	}
+}
+
+func TestNewPool_Validation(t *testing.T) {
+	if _, err := NewPool(nil, WithQuietMode(true)); !errors.Is(err, ErrEmptyCluster) {
+		t.Errorf("Expected ErrEmptyCluster, got %v", err)
+	}
+	if _, err := NewPool([]int{1, 2, 1}, WithQuietMode(true)); !errors.Is(err, ErrDuplicateClusterID) {
+		t.Errorf("Expected ErrDuplicateClusterID, got %v", err)
+	}
+	for _, ids := range [][]int{{0, int(NodeMax) + 1}, {-1}} {
+		if _, err := NewPool(ids, WithQuietMode(true)); !errors.Is(err, ErrInvalidNodeID) {
+			t.Errorf("NewPool(%v): expected ErrInvalidNodeID, got %v", ids, err)
+		}
+	}
+}
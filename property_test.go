@@ -0,0 +1,109 @@
+package arbiterid
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tickingClock is a ManualClock that also moves forward a millisecond every tickEvery
+// reads, the way a real clock passes while Generate exhausts a millisecond and waits.
+type tickingClock struct {
+	*ManualClock
+	reads     atomic.Int64
+	tickEvery int64
+}
+
+// Now advances the clock on every tickEvery-th read, then returns its time
+func (c *tickingClock) Now() time.Time {
+	if c.reads.Add(1)%c.tickEvery == 0 {
+		c.Advance(time.Millisecond)
+	}
+	return c.ManualClock.Now()
+}
+
+// TestGenerate_ClockManipulationProperty drives Generate from a manual clock that randomly
+// stalls, jumps forward, and moves backwards, and checks the generation invariants over
+// every ID produced: unique, positive, and strictly increasing when checks are enabled.
+func TestGenerate_ClockManipulationProperty(t *testing.T) {
+	tests := []struct {
+		name      string
+		strict    bool
+		types     []IDType
+		steps     int
+		burstMin  int
+		burstMax  int
+		maxBack   int   // Largest backward move, in milliseconds; 0 never moves back
+		tickEvery int64 // Clock reads per millisecond; 0 leaves the clock to the test
+	}{
+		{"Strict single type", true, []IDType{testType1}, 3000, 1, 20, 3, 0},
+		{"Non-strict mixed types", false, []IDType{testType0, testType1, 42, testTypeMax}, 3000, 1, 20, 3, 0},
+		// One read per millisecond more than there are sequences, so bursts run a
+		// millisecond dry and roll over. The clock never moves back, as each millisecond
+		// it lags would cost tickEvery reads of the rollover wait.
+		{"Sequence rollover", true, []IDType{testType1}, 30, int(SeqMax) + 2, 2 * int(SeqMax+1), 0, SeqMax + 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			clock := NewManualClock(time.Now().UTC().Truncate(time.Millisecond))
+			clockOpt := WithManualClock(clock)
+			if tt.tickEvery > 0 {
+				clockOpt = WithClock(&tickingClock{ManualClock: clock, tickEvery: tt.tickEvery})
+			}
+			node := newTestNode(t, testNodeID0, WithQuietMode(true), clockOpt,
+				WithStrictMonotonicityCheck(tt.strict))
+
+			seen := make(map[ID]bool)
+			var last ID
+			for step := 0; step < tt.steps; step++ {
+				// Backward moves are kept small relative to forward ones: while the clock
+				// is behind, every ID shares the node's last millisecond, and a manual
+				// clock would never let a full millisecond roll over.
+				switch r := rng.IntN(100); {
+				case r < 60:
+					// Stall: leave the clock where it is
+				case r < 90:
+					clock.Advance(time.Duration(1+rng.IntN(5)) * time.Millisecond)
+				case tt.maxBack == 0:
+					// Stall instead of moving back
+				default:
+					clock.Advance(-time.Duration(1+rng.IntN(tt.maxBack)) * time.Millisecond)
+				}
+
+				for i, burst := 0, tt.burstMin+rng.IntN(tt.burstMax-tt.burstMin+1); i < burst; i++ {
+					idType := tt.types[rng.IntN(len(tt.types))]
+					id, err := node.Generate(idType)
+					if err != nil {
+						t.Fatalf("Step %d: Generate failed: %v", step, err)
+					}
+					if id <= 0 {
+						t.Fatalf("Step %d: non-positive ID %d", step, id)
+					}
+					if seen[id] {
+						t.Fatalf("Step %d: duplicate ID %d", step, id)
+					}
+					seen[id] = true
+					if IDType(id.Type()) != idType {
+						t.Fatalf("Step %d: ID %d has type %d, want %d", step, id, id.Type(), idType)
+					}
+					if tt.strict && id <= last {
+						t.Fatalf("Step %d: ID %d not greater than previous %d", step, id, last)
+					}
+					last = id
+				}
+			}
+			if tt.maxBack > 1 && node.Snapshot().ClockWarningCount == 0 {
+				t.Error("Clock never moved far enough backwards to exercise the rollback path")
+			}
+			if tt.tickEvery > 0 && node.Stats().SequenceRollovers == 0 {
+				t.Error("Bursts never exhausted a millisecond's sequences")
+			}
+			if got := node.GeneratedCount(); got != int64(len(seen)) {
+				t.Errorf("GeneratedCount %d, but %d unique IDs seen", got, len(seen))
+			}
+		})
+	}
+}
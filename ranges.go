@@ -0,0 +1,102 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimestampBeforeEpoch is returned when a time predates the ID epoch and so cannot be encoded.
+var ErrTimestampBeforeEpoch = errors.New("arbiterid: timestamp is before the epoch")
+
+// IDsForMillis returns every ID that can exist for the given type, node, and millisecond
+// of t (using the package Epoch): SeqMax+1 IDs with sequences 0 through SeqMax, in
+// ascending order. It is intended for exhaustive tests, such as checking that a
+// partition handles a full millisecond of IDs.
+func IDsForMillis(idType IDType, t time.Time, nodeID int64) ([]ID, error) {
+	if uint16(idType) > TypeMax {
+		return nil, fmt.Errorf("%w: got %d, max %d", ErrInvalIDType, idType, TypeMax)
+	}
+	if nodeID < 0 || nodeID > NodeMax {
+		return nil, fmt.Errorf("%w: got %d, max %d", ErrInvalidNodeID, nodeID, NodeMax)
+	}
+	millis, err := epochMillis(t)
+	if err != nil {
+		return nil, err
+	}
+
+	base := int64(idType)<<TypeShift | millis<<TimeShift | nodeID<<NodeShift
+	ids := make([]ID, SeqMax+1)
+	for seq := range ids {
+		ids[seq] = ID(base | int64(seq))
+	}
+	return ids, nil
+}
+
+// epochMillis converts t to milliseconds since the package Epoch, checking that it fits
+// the timestamp field.
+func epochMillis(t time.Time) (int64, error) {
+	millis := t.UnixMilli() - Epoch
+	if millis < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrTimestampBeforeEpoch, t.UTC().Format(time.RFC3339Nano))
+	}
+	if millis > TimestampMax {
+		return 0, fmt.Errorf("%w: %dms exceeds maximum %dms", ErrTimestampOverflow, millis, TimestampMax)
+	}
+	return millis, nil
+}
+
+// IDRangeForInterval returns the inclusive range [first, last] of IDs of the given type
+// timestamped within [start, end] (using the package Epoch), across all nodes and
+// sequences. Because the type occupies the most significant bits, a time range is only
+// contiguous within one type.
+func IDRangeForInterval(idType IDType, start, end time.Time) ([2]ID, error) {
+	if uint16(idType) > TypeMax {
+		return [2]ID{}, fmt.Errorf("%w: got %d, max %d", ErrInvalIDType, idType, TypeMax)
+	}
+	if end.Before(start) {
+		return [2]ID{}, fmt.Errorf("arbiterid: interval end %s is before start %s",
+			end.UTC().Format(time.RFC3339Nano), start.UTC().Format(time.RFC3339Nano))
+	}
+	from, err := epochMillis(start)
+	if err != nil {
+		return [2]ID{}, err
+	}
+	to, err := epochMillis(end)
+	if err != nil {
+		return [2]ID{}, err
+	}
+
+	typeBits := int64(idType) << TypeShift
+	return [2]ID{
+		ID(typeBits | from<<TimeShift),
+		ID(typeBits | to<<TimeShift | NodeMask | SeqMask),
+	}, nil
+}
+
+// IDRangeIntersect returns the overlap of two inclusive ID ranges, each given as
+// [first, last], or ok=false if they are disjoint. Ranges sharing only an endpoint
+// intersect in that single ID.
+func IDRangeIntersect(a, b [2]ID) ([2]ID, bool) {
+	lo, hi := max(a[0], b[0]), min(a[1], b[1])
+	if lo > hi {
+		return [2]ID{}, false
+	}
+	return [2]ID{lo, hi}, true
+}
+
+// TimeSpan returns the earliest and latest timestamps embedded in ids, in one pass and
+// regardless of order, e.g. to summarize an export. Timestamps are read with the package
+// Epoch, as by ID.TimeTime. An empty slice yields zero times.
+func TimeSpan(ids []ID) (earliest, latest time.Time) {
+	if len(ids) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	lo, hi := ids[0].Time(), ids[0].Time()
+	for _, id := range ids[1:] {
+		ms := id.Time()
+		lo = min(lo, ms)
+		hi = max(hi, ms)
+	}
+	return time.UnixMilli(lo).UTC(), time.UnixMilli(hi).UTC()
+}
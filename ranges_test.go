@@ -0,0 +1,137 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIDsForMillis(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 30, 0, 123456789, time.UTC)
+	ids, err := IDsForMillis(42, ts, testNodeID1)
+	if err != nil {
+		t.Fatalf("IDsForMillis failed: %v", err)
+	}
+	if int64(len(ids)) != SeqMax+1 {
+		t.Fatalf("Expected %d IDs, got %d", SeqMax+1, len(ids))
+	}
+
+	seen := make(map[ID]bool, len(ids))
+	for i, id := range ids {
+		if seen[id] {
+			t.Errorf("Duplicate ID %d", id)
+		}
+		seen[id] = true
+		if id.Time() != ts.UnixMilli() || id.Type() != 42 || id.Node() != testNodeID1 || id.Seq() != int64(i) {
+			t.Errorf("ID %d at index %d has components type=%d time=%d node=%d seq=%d",
+				id, i, id.Type(), id.Time(), id.Node(), id.Seq())
+		}
+	}
+
+	// A node generating at that millisecond produces the first of them
+	node := newTestNode(t, testNodeID1, WithQuietMode(true))
+	if id, _ := node.GenerateWithTimestamp(42, ts); id != ids[0] {
+		t.Errorf("Generated ID %d, want %d", id, ids[0])
+	}
+}
+
+func TestIDsForMillis_Invalid(t *testing.T) {
+	now := time.Now()
+	if _, err := IDsForMillis(IDType(TypeMax+1), now, 0); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType, got %v", err)
+	}
+	if _, err := IDsForMillis(0, now, NodeMax+1); !errors.Is(err, ErrInvalidNodeID) {
+		t.Errorf("Expected ErrInvalidNodeID, got %v", err)
+	}
+	if _, err := IDsForMillis(0, time.UnixMilli(Epoch-1), 0); !errors.Is(err, ErrTimestampBeforeEpoch) {
+		t.Errorf("Expected ErrTimestampBeforeEpoch, got %v", err)
+	}
+	if _, err := IDsForMillis(0, time.UnixMilli(Epoch+TimestampMax+1), 0); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow, got %v", err)
+	}
+}
+
+func TestIDRangeIntersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   [2]ID
+		want   [2]ID
+		wantOK bool
+	}{
+		{"Overlapping", [2]ID{10, 50}, [2]ID{30, 80}, [2]ID{30, 50}, true},
+		{"Contained", [2]ID{10, 80}, [2]ID{30, 50}, [2]ID{30, 50}, true},
+		{"Shared endpoint", [2]ID{10, 30}, [2]ID{30, 50}, [2]ID{30, 30}, true},
+		{"Adjacent", [2]ID{10, 29}, [2]ID{30, 50}, [2]ID{}, false},
+		{"Disjoint", [2]ID{60, 80}, [2]ID{10, 50}, [2]ID{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := IDRangeIntersect(tt.a, tt.b)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("IDRangeIntersect(%v, %v) = %v, %t; want %v, %t", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+			}
+			if rev, revOK := IDRangeIntersect(tt.b, tt.a); rev != got || revOK != ok {
+				t.Errorf("IDRangeIntersect is not symmetric: %v, %t", rev, revOK)
+			}
+		})
+	}
+}
+
+func TestIDRangeForInterval(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	morning, err := IDRangeForInterval(testType1, start, start.Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("IDRangeForInterval failed: %v", err)
+	}
+
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	inside, _ := node.GenerateWithTimestamp(testType1, start.Add(time.Hour))
+	if inside < morning[0] || inside > morning[1] {
+		t.Errorf("ID %d generated inside the interval falls outside %v", inside, morning)
+	}
+	if morning[0].Time() != start.UnixMilli() || morning[1].Time() != start.Add(12*time.Hour).UnixMilli() {
+		t.Errorf("Range %v does not span the interval", morning)
+	}
+
+	// Two windows overlapping by an hour intersect in that hour's IDs
+	later, _ := IDRangeForInterval(testType1, start.Add(11*time.Hour), start.Add(20*time.Hour))
+	overlap, ok := IDRangeIntersect(morning, later)
+	if !ok || overlap[0] != later[0] || overlap[1] != morning[1] {
+		t.Errorf("Overlap = %v, %t; want [%d %d]", overlap, ok, later[0], morning[1])
+	}
+
+	if _, err := IDRangeForInterval(testType1, start, start.Add(-time.Second)); err == nil {
+		t.Error("Expected error for reversed interval")
+	}
+}
+
+func TestTimeSpan(t *testing.T) {
+	if earliest, latest := TimeSpan(nil); !earliest.IsZero() || !latest.IsZero() {
+		t.Errorf("TimeSpan(nil) = %s, %s; want zero times", earliest, latest)
+	}
+
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	var ids []ID
+	for _, offset := range []time.Duration{0, 30 * time.Minute, 90 * time.Minute, 2 * time.Hour} {
+		id, err := node.GenerateWithTimestamp(testType1, start.Add(offset))
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	// Order does not matter
+	ids[0], ids[2] = ids[2], ids[0]
+
+	earliest, latest := TimeSpan(ids)
+	if !earliest.Equal(start) || !latest.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("TimeSpan = %s, %s; want %s, %s", earliest, latest, start, start.Add(2*time.Hour))
+	}
+	if span := latest.Sub(earliest); span != 2*time.Hour {
+		t.Errorf("Span = %s, want 2h", span)
+	}
+
+	if earliest, latest := TimeSpan(ids[1:2]); !earliest.Equal(latest) {
+		t.Errorf("Single-ID span = %s, %s; want equal times", earliest, latest)
+	}
+}
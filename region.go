@@ -0,0 +1,62 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Region code layout within the type field
+const (
+	RegionBits uint8 = 3                     // Top type bits reserved by WithRegionCode
+	RegionMax  uint8 = (1 << RegionBits) - 1 // Largest region code (7)
+)
+
+// ErrInvalidRegionCode is returned by NewNode when a WithRegionCode code does not fit
+// RegionBits or the node's type field cannot spare them.
+var ErrInvalidRegionCode = errors.New("arbiterid: invalid region code")
+
+// WithRegionCode stamps code into the top RegionBits bits of the type field of every ID
+// the node generates, so an ID reveals which region minted it for routing or compliance.
+// This carves up the type space: the node accepts types up to TypeMax>>RegionBits (127
+// with the default layout) and ORs the region in above them. Read the parts back with
+// ID.Region and ID.TypeBase(RegionBits); ID.Type returns the combined field.
+//
+// code must be at most RegionMax, otherwise NewNode returns ErrInvalidRegionCode, as it
+// does when combined with WithTypeVersioning, which claims the same bits, or with a
+// WithBitLayout type field too narrow to spare them.
+func WithRegionCode(code uint8) NodeOption {
+	return func(n *Node) {
+		n.region = code
+		n.hasRegion = true
+	}
+}
+
+// validateRegion checks the WithRegionCode settings against the node's layout.
+func (n *Node) validateRegion() error {
+	switch {
+	case !n.hasRegion:
+		return nil
+	case n.region > RegionMax:
+		return fmt.Errorf("%w: got %d, max %d", ErrInvalidRegionCode, n.region, RegionMax)
+	case RegionBits >= n.layout.TypeBits:
+		return fmt.Errorf("%w: %d type bits cannot hold %d region bits", ErrInvalidRegionCode, n.layout.TypeBits, RegionBits)
+	case n.typeVersionBits != 0:
+		return fmt.Errorf("%w: cannot be combined with WithTypeVersioning", ErrInvalidRegionCode)
+	}
+	return nil
+}
+
+// stampType returns idType with the node's region code, if any, in its top bits.
+func (n *Node) stampType(idType IDType) IDType {
+	if !n.hasRegion {
+		return idType
+	}
+	return idType | IDType(n.region)<<(n.layout.TypeBits-RegionBits)
+}
+
+// Region returns the region code stamped by WithRegionCode: the top RegionBits bits of
+// the type, assuming the default layout. For IDs from a node without a region code it
+// returns the top bits of the plain type, which are zero for types up to 127.
+func (id ID) Region() uint8 {
+	return uint8(id.Type() >> (TypeBits - RegionBits))
+}
@@ -0,0 +1,73 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithRegionCode(t *testing.T) {
+	node := newTestNode(t, testNodeID1, WithQuietMode(true), WithRegionCode(5), WithTypeAgnosticMonotonicity(true))
+
+	for _, idType := range []IDType{testType0, testType1, 127} {
+		id, err := node.Generate(idType)
+		if err != nil {
+			t.Fatalf("Generate(%d) failed: %v", idType, err)
+		}
+		if got := id.Region(); got != 5 {
+			t.Errorf("Region() = %d, want 5", got)
+		}
+		if got := id.TypeBase(RegionBits); got != int64(idType) {
+			t.Errorf("TypeBase(RegionBits) = %d, want %d", got, idType)
+		}
+		if got, want := id.Type(), int64(5<<7|idType); got != want {
+			t.Errorf("Type() = %d, want %d", got, want)
+		}
+		if id.Node() != testNodeID1 {
+			t.Errorf("Node() = %d, want %d", id.Node(), testNodeID1)
+		}
+	}
+
+	// The region's bits are not available to the type
+	if _, err := node.Generate(128); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType for type 128, got %v", err)
+	}
+
+	// IDs from other generation paths carry the region too
+	batch, err := node.GenerateBatch(testType1, 3)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	child, err := node.GenerateChild(testType1, batch[2])
+	if err != nil {
+		t.Fatalf("GenerateChild failed: %v", err)
+	}
+	for _, id := range append(batch, child) {
+		if id.Region() != 5 || id.TypeBase(RegionBits) != int64(testType1) {
+			t.Errorf("ID %d has region %d and base type %d", id, id.Region(), id.TypeBase(RegionBits))
+		}
+	}
+
+	plain := newTestNode(t, testNodeID0, WithQuietMode(true)).GenerateSimple(testType1)
+	if plain.Region() != 0 {
+		t.Errorf("Expected region 0 without WithRegionCode, got %d", plain.Region())
+	}
+}
+
+func TestWithRegionCode_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []NodeOption
+	}{
+		{"Code too large", []NodeOption{WithRegionCode(RegionMax + 1)}},
+		{"With type versioning", []NodeOption{WithRegionCode(1), WithTypeVersioning(2)}},
+		{"Type field too narrow", []NodeOption{WithRegionCode(1), WithBitLayout(3, 2, 10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNode(testNodeID0, append(tt.opts, WithQuietMode(true))...)
+			if !errors.Is(err, ErrInvalidRegionCode) {
+				t.Errorf("Expected ErrInvalidRegionCode, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+)
+
+// Type registry errors
+var (
+	ErrUnregisteredType  = errors.New("arbiterid: ID type is not registered")
+	ErrDuplicateTypeName = errors.New("arbiterid: ID type or name is already registered")
+)
+
+// TypeRegistry maps the ID types an application uses to their names, documenting which
+// types exist. Populate it at startup with Register; it is not safe for concurrent
+// modification.
+type TypeRegistry map[IDType]string
+
+// Register adds idType under name. It returns ErrInvalIDType for a type above TypeMax and
+// ErrDuplicateTypeName if the type or the name is already registered.
+func (r TypeRegistry) Register(idType IDType, name string) error {
+	if idType > IDType(TypeMax) {
+		return fmt.Errorf("%w: got %d, max %d", ErrInvalIDType, idType, TypeMax)
+	}
+	if existing, ok := r[idType]; ok {
+		return fmt.Errorf("%w: type %d is registered as %q", ErrDuplicateTypeName, idType, existing)
+	}
+	for t, existing := range r {
+		if existing == name {
+			return fmt.Errorf("%w: name %q is registered for type %d", ErrDuplicateTypeName, name, t)
+		}
+	}
+	r[idType] = name
+	return nil
+}
+
+// WithRegisteredTypesOnly makes Generate, and every other generation method, reject types
+// not present in registry with ErrUnregisteredType, so only documented types are ever
+// minted. The registry is copied, so types registered afterwards are not accepted.
+// A nil registry disables the check; an empty one rejects every type.
+func WithRegisteredTypesOnly(registry TypeRegistry) NodeOption {
+	return func(n *Node) {
+		n.registeredTypes = maps.Clone(registry)
+	}
+}
+
+// typeNames is the process-wide registry behind RegisterType and ID.TypeName.
+var typeNames = struct {
+	sync.RWMutex
+	registry TypeRegistry
+}{registry: TypeRegistry{}}
+
+// RegisterType names idType in the process-wide registry, so ID.TypeName and log output
+// show the name instead of the number. Call it at startup, e.g. from an init function
+// next to the type constants. Like TypeRegistry.Register, it returns ErrInvalIDType for a
+// type above TypeMax and ErrDuplicateTypeName if the type or the name is already
+// registered. It is safe for concurrent use.
+func RegisterType(idType IDType, name string) error {
+	typeNames.Lock()
+	defer typeNames.Unlock()
+	return typeNames.registry.Register(idType, name)
+}
+
+// RegisteredTypes returns a copy of the types registered with RegisterType, for example
+// to pass to WithRegisteredTypesOnly or to list in a service's info endpoint.
+func RegisteredTypes() TypeRegistry {
+	typeNames.RLock()
+	defer typeNames.RUnlock()
+	return maps.Clone(typeNames.registry)
+}
+
+// TypeName returns the name registered for the ID's type with RegisterType, or the type
+// in decimal if it has none.
+func (id ID) TypeName() string {
+	idType := IDType(id.Type())
+	typeNames.RLock()
+	name, ok := typeNames.registry[idType]
+	typeNames.RUnlock()
+	if !ok {
+		return strconv.FormatInt(int64(idType), 10)
+	}
+	return name
+}
@@ -0,0 +1,92 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTypeRegistry_Register(t *testing.T) {
+	r := TypeRegistry{}
+	if err := r.Register(testType1, "user"); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := r.Register(testType1, "account"); !errors.Is(err, ErrDuplicateTypeName) {
+		t.Errorf("Expected ErrDuplicateTypeName for a re-registered type, got %v", err)
+	}
+	if err := r.Register(testTypeMax, "user"); !errors.Is(err, ErrDuplicateTypeName) {
+		t.Errorf("Expected ErrDuplicateTypeName for a duplicate name, got %v", err)
+	}
+	if err := r.Register(IDType(TypeMax+1), "overflow"); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType, got %v", err)
+	}
+	if len(r) != 1 || r[testType1] != "user" {
+		t.Errorf("Registry = %v, want only type %d as user", r, testType1)
+	}
+}
+
+func TestWithRegisteredTypesOnly(t *testing.T) {
+	registry := TypeRegistry{}
+	if err := registry.Register(testType1, "user"); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithRegisteredTypesOnly(registry))
+
+	if _, err := node.Generate(testType1); err != nil {
+		t.Errorf("Generate of a registered type failed: %v", err)
+	}
+	if _, err := node.Generate(testTypeMax); !errors.Is(err, ErrUnregisteredType) {
+		t.Errorf("Expected ErrUnregisteredType from Generate, got %v", err)
+	}
+	if _, err := node.GenerateBatch(testTypeMax, 3); !errors.Is(err, ErrUnregisteredType) {
+		t.Errorf("Expected ErrUnregisteredType from GenerateBatch, got %v", err)
+	}
+
+	// The node keeps its own copy of the registry
+	if err := registry.Register(testTypeMax, "post"); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := node.Generate(testTypeMax); !errors.Is(err, ErrUnregisteredType) {
+		t.Errorf("Type registered after NewNode should still be rejected, got %v", err)
+	}
+}
+
+// resetTypeNames empties the process-wide type registry when the test ends.
+func resetTypeNames(t *testing.T) {
+	t.Cleanup(func() {
+		typeNames.Lock()
+		typeNames.registry = TypeRegistry{}
+		typeNames.Unlock()
+	})
+}
+
+func TestRegisterType_TypeName(t *testing.T) {
+	resetTypeNames(t)
+	if err := RegisterType(512, "post"); err != nil {
+		t.Fatalf("RegisterType failed: %v", err)
+	}
+	if err := RegisterType(testType1, "user"); err != nil {
+		t.Fatalf("RegisterType failed: %v", err)
+	}
+	if err := RegisterType(testTypeMax, "post"); !errors.Is(err, ErrDuplicateTypeName) {
+		t.Errorf("Expected ErrDuplicateTypeName for a duplicate name, got %v", err)
+	}
+	if err := RegisterType(IDType(TypeMax+1), "overflow"); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType, got %v", err)
+	}
+
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false))
+	for idType, want := range map[IDType]string{512: "post", testType1: "user", 42: "42", testType0: "0"} {
+		if got := node.GenerateSimple(idType).TypeName(); got != want {
+			t.Errorf("TypeName() for type %d = %q, want %q", idType, got, want)
+		}
+	}
+
+	registered := RegisteredTypes()
+	if len(registered) != 2 || registered[512] != "post" {
+		t.Errorf("RegisteredTypes() = %v", registered)
+	}
+	registered[7] = "mutated"
+	if got := (ID(int64(7) << TypeShift)).TypeName(); got != "7" {
+		t.Errorf("Mutating the RegisteredTypes copy changed the registry: TypeName() = %q", got)
+	}
+}
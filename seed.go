@@ -0,0 +1,46 @@
+package arbiterid
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// seedSpan is the range after the package Epoch in which GenerateFromSeed starts its
+// sequence. It lies in the past, so the fixtures stay valid whatever the current time.
+const seedSpan = 365 * 24 * time.Hour
+
+// GenerateFromSeed returns n IDs of the given type and node derived deterministically from
+// seed, for use as test fixtures that exercise parsing and encoding without a live Node.
+// The same arguments always yield the same IDs. They are unique, strictly increasing, pass
+// Validate, and use the default layout and package Epoch, with timestamps in the first year
+// after the Epoch; they are not IDs a Node would have generated.
+//
+// It panics if idType or node is out of range or n is negative.
+func GenerateFromSeed(seed int64, idType IDType, node int64, n int) []ID {
+	if n < 0 {
+		panic(fmt.Sprintf("arbiterid: GenerateFromSeed called with negative count %d", n))
+	}
+	rng := rand.New(rand.NewPCG(uint64(seed), uint64(seed)>>32|0x9e3779b97f4a7c15))
+	millis := Epoch + rng.Int64N(seedSpan.Milliseconds())
+	seq := rng.Int64N(SeqMax + 1)
+
+	ids := make([]ID, n)
+	for i := range ids {
+		if i > 0 {
+			// Mostly stay in the same millisecond, sometimes skip ahead a little
+			if seq == SeqMax || rng.IntN(4) == 0 {
+				millis += 1 + rng.Int64N(5)
+				seq = rng.Int64N(SeqMax / 2)
+			} else {
+				seq += 1 + rng.Int64N(min(3, SeqMax-seq))
+			}
+		}
+		id, err := FromComponents(idType, millis, node, seq)
+		if err != nil {
+			panic(fmt.Sprintf("arbiterid: GenerateFromSeed: %v", err))
+		}
+		ids[i] = id
+	}
+	return ids
+}
@@ -0,0 +1,63 @@
+package arbiterid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerateFromSeed(t *testing.T) {
+	const count = 5000
+
+	ids := GenerateFromSeed(42, testType1, testNodeID1, count)
+	if len(ids) != count {
+		t.Fatalf("Expected %d IDs, got %d", count, len(ids))
+	}
+	if again := GenerateFromSeed(42, testType1, testNodeID1, count); !slices.Equal(ids, again) {
+		t.Error("Same seed produced different IDs")
+	}
+	if other := GenerateFromSeed(43, testType1, testNodeID1, count); slices.Equal(ids, other) {
+		t.Error("Different seeds produced the same IDs")
+	}
+
+	for i, id := range ids {
+		if err := id.Validate(); err != nil {
+			t.Fatalf("ID %d (%d) is not valid: %v", i, id, err)
+		}
+		if IDType(id.Type()) != testType1 || id.Node() != testNodeID1 {
+			t.Fatalf("ID %d has type %d node %d, want %d and %d", i, id.Type(), id.Node(), testType1, testNodeID1)
+		}
+		if i > 0 && id <= ids[i-1] {
+			t.Fatalf("ID %d (%d) not greater than previous (%d)", i, id, ids[i-1])
+		}
+		if parsed, err := ParseBase58(id.Base58()); err != nil || parsed != id {
+			t.Fatalf("Base58 round trip of %d gave %d, %v", id, parsed, err)
+		}
+	}
+
+	if got := GenerateFromSeed(1, testTypeMax, NodeMax, 0); len(got) != 0 {
+		t.Errorf("Expected no IDs for n=0, got %d", len(got))
+	}
+}
+
+func TestGenerateFromSeed_PanicsOnInvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		idType IDType
+		node   int64
+		n      int
+	}{
+		{"Type out of range", IDType(TypeMax + 1), testNodeID0, 1},
+		{"Node out of range", testType1, NodeMax + 1, 1},
+		{"Negative count", testType1, testNodeID0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected a panic")
+				}
+			}()
+			GenerateFromSeed(1, tt.idType, tt.node, tt.n)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package arbiterid
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// selfCheckSamples is the number of random IDs checked in addition to the boundary cases
+const selfCheckSamples = 16
+
+// WithSelfCheck enables a consistency check in NewNode that packs a handful of IDs across
+// boundary and random component values, decodes them again, and verifies every field
+// round-trips. Default is false. It catches broken layout math early; NewNode returns
+// ErrSelfCheckFailed if any sample does not round-trip. The check does not consume
+// sequences or otherwise change node state.
+func WithSelfCheck(enable bool) NodeOption {
+	return func(n *Node) {
+		n.selfCheck = enable
+	}
+}
+
+// runSelfCheck verifies that IDs packed by the node decode back to their components.
+func (n *Node) runSelfCheck() error {
+	type sample struct {
+		idType IDType
+		millis int64
+		seq    int64
+	}
+	samples := []sample{
+		{0, 0, 0},
+		{IDType(n.layout.typeMax()), n.layout.timeMax(), n.layout.seqMax()},
+		{1, n.currentMillis(), 1},
+	}
+	for i := 0; i < selfCheckSamples; i++ {
+		samples = append(samples, sample{
+			idType: IDType(rand.Int64N(n.layout.typeMax() + 1)),
+			millis: rand.Int64N(n.layout.timeMax() + 1),
+			seq:    rand.Int64N(n.layout.seqMax() + 1),
+		})
+	}
+
+	// A zero epoch makes the decoded time the raw millisecond field
+	d := NewDecoderOnly(0, n.layout)
+	for _, s := range samples {
+		id := n.pack(s.idType, s.millis, s.seq)
+		idType, millis, node, seq := d.Components(id)
+		if id < 0 || idType != n.stampType(s.idType) || millis != s.millis || node != n.node || seq != s.seq {
+			return fmt.Errorf("%w: packed type=%d time=%d node=%d seq=%d into %d, decoded type=%d time=%d node=%d seq=%d",
+				ErrSelfCheckFailed, s.idType, s.millis, n.node, s.seq, id, idType, millis, node, seq)
+		}
+	}
+	return nil
+}
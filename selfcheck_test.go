@@ -0,0 +1,15 @@
+package arbiterid
+
+import "testing"
+
+func TestWithSelfCheck_DefaultLayout(t *testing.T) {
+	for nodeID := 0; nodeID <= int(NodeMax); nodeID++ {
+		node, err := NewNode(nodeID, WithQuietMode(true), WithSelfCheck(true))
+		if err != nil {
+			t.Fatalf("NewNode(%d) with self-check failed: %v", nodeID, err)
+		}
+		if node.LastID() != 0 {
+			t.Errorf("Self-check should not change node state, LastID = %d", node.LastID())
+		}
+	}
+}
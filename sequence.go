@@ -0,0 +1,112 @@
+package arbiterid
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	"sync/atomic"
+)
+
+// SequenceAllocator hands out the sequence component for IDs generated within a millisecond.
+//
+// Next is called with the millisecond (relative to the node's epoch) of each ID the node is
+// about to generate and returns the sequence to use, or ok=false when no sequence remains for
+// that millisecond. Within a millisecond, returned sequences must be strictly increasing and
+// within [0, SeqMax]; the node rejects out-of-range values with ErrInvalidSequence.
+// Next is always called with the node's mutex held, so implementations need no locking of
+// their own unless shared between nodes.
+type SequenceAllocator interface {
+	Next(millis int64) (seq int64, ok bool)
+}
+
+// incrementAllocator is the default allocator: sequences start at 0 each millisecond (or
+// at a random value with WithRandomSequenceStart) and increase by one until SeqMax is
+// reached. Its millisecond and sequence share one atomic word, so GenerateAtomic can take
+// sequences without the node's mutex. Sequences claimed by GenerateForKey are skipped.
+type incrementAllocator struct {
+	state       atomic.Int64 // millis<<seqBits | seq, or noMillis before the first call
+	seqBits     uint8
+	max         int64 // The node's SeqMax, which depends on its layout
+	randomStart bool  // Start each millisecond at a random sequence in [0, max/2]
+
+	keyed atomic.Pointer[keyedSeqs] // GenerateForKey's claims in its latest millisecond
+}
+
+// noMillis marks an incrementAllocator that has not handed out any sequence yet
+const noMillis = math.MinInt64
+
+// newIncrementAllocator returns an incrementAllocator for a sequence field of seqBits bits.
+func newIncrementAllocator(seqBits uint8) *incrementAllocator {
+	a := &incrementAllocator{seqBits: seqBits, max: 1<<seqBits - 1}
+	a.state.Store(noMillis)
+	return a
+}
+
+// Next implements SequenceAllocator
+func (a *incrementAllocator) Next(millis int64) (int64, bool) {
+	for {
+		keyed := a.keyed.Load()
+		state := a.state.Load()
+		next := millis << a.seqBits
+		if state != noMillis && state>>a.seqBits == millis {
+			if state&a.max >= a.max {
+				return 0, false
+			}
+			next = state + 1
+		} else if a.randomStart {
+			next |= randomSequenceStart(a.max / 2)
+		}
+		if a.state.CompareAndSwap(state, next) && !a.claimedByKey(keyed, millis, next&a.max) {
+			return next & a.max, true
+		}
+	}
+}
+
+// nextInMillis takes the next sequence only if the allocator is already in millis,
+// returning ok=false otherwise or when the millisecond is exhausted.
+func (a *incrementAllocator) nextInMillis(millis int64) (int64, bool) {
+	for {
+		keyed := a.keyed.Load()
+		state := a.state.Load()
+		if state == noMillis || state>>a.seqBits != millis || state&a.max >= a.max {
+			return 0, false
+		}
+		if a.state.CompareAndSwap(state, state+1) && !a.claimedByKey(keyed, millis, (state+1)&a.max) {
+			return (state + 1) & a.max, true
+		}
+	}
+}
+
+// randomSequenceStart returns a uniformly random sequence in [0, limit] from crypto/rand.
+func randomSequenceStart(limit int64) int64 {
+	v, err := rand.Int(rand.Reader, big.NewInt(limit+1))
+	if err != nil {
+		return 0 // The system's random source failed; fall back to the plain start
+	}
+	return v.Int64()
+}
+
+// WithRandomSequenceStart makes the default allocator start each millisecond at a random
+// sequence in [0, SeqMax/2], drawn from crypto/rand, instead of 0. Sequences still increase
+// by one within the millisecond, so IDs stay unique and ordered, but the sequence no longer
+// reveals how many IDs were generated before it, which makes guessing neighbouring IDs and
+// estimating throughput harder. The cost is capacity: a millisecond holds between SeqMax/2+1
+// and SeqMax+1 IDs (at least 512 with the default layout) before Generate waits for the
+// next one, and batches may spread over more milliseconds. Peek and RemainingSequence do
+// not account for the random start. The option has no effect with WithSequenceAllocator.
+// Default is false.
+func WithRandomSequenceStart(enable bool) NodeOption {
+	return func(n *Node) {
+		n.randomSequenceStart = enable
+	}
+}
+
+// WithSequenceAllocator replaces the default increment-and-wrap sequence allocation.
+// A nil allocator is ignored.
+func WithSequenceAllocator(allocator SequenceAllocator) NodeOption {
+	return func(n *Node) {
+		if allocator != nil {
+			n.seqAllocator = allocator
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// evenAllocator hands out only even sequences: 0, 2, 4, ...
+type evenAllocator struct {
+	millis int64
+	seq    int64
+}
+
+func (a *evenAllocator) Next(millis int64) (int64, bool) {
+	if millis != a.millis {
+		a.millis = millis
+		a.seq = 0
+		return 0, true
+	}
+	if a.seq+2 > SeqMax {
+		return 0, false
+	}
+	a.seq += 2
+	return a.seq, true
+}
+
+// constantAllocator always returns the same sequence.
+type constantAllocator int64
+
+func (a constantAllocator) Next(int64) (int64, bool) { return int64(a), true }
+
+func TestSequenceAllocator_Custom(t *testing.T) {
+	fixed := time.Now().UTC().Add(time.Minute)
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithSequenceAllocator(&evenAllocator{millis: -1}))
+
+	// Half of the sequence space is available per millisecond
+	for i := 0; i <= int(SeqMax/2); i++ {
+		id, err := node.GenerateWithTimestamp(testType1, fixed)
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed at iteration %d: %v", i, err)
+		}
+		if id.Seq() != int64(i*2) {
+			t.Errorf("Expected sequence %d, got %d", i*2, id.Seq())
+		}
+	}
+	if _, err := node.GenerateWithTimestamp(testType1, fixed); !errors.Is(err, ErrClockNotAdvancing) {
+		t.Errorf("Expected ErrClockNotAdvancing once even sequences are exhausted, got %v", err)
+	}
+
+	generated := newTestNode(t, testNodeID0, WithQuietMode(true), WithSequenceAllocator(&evenAllocator{millis: -1}))
+	for i := 0; i < 100; i++ {
+		id, err := generated.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed at iteration %d: %v", i, err)
+		}
+		if id.Seq()%2 != 0 {
+			t.Errorf("Expected even sequence, got %d", id.Seq())
+		}
+	}
+}
+
+func TestSequenceAllocator_OutOfRange(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithSequenceAllocator(constantAllocator(SeqMax+1)))
+	if _, err := node.Generate(testType1); !errors.Is(err, ErrInvalidSequence) {
+		t.Errorf("Expected ErrInvalidSequence, got %v", err)
+	}
+}
+
+func TestWithRandomSequenceStart(t *testing.T) {
+	t.Run("Start", func(t *testing.T) {
+		clock := NewManualClock(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(clock), WithRandomSequenceStart(true))
+
+		nonZero := 0
+		for i := 0; i < 50; i++ {
+			clock.Advance(time.Millisecond)
+			id, err := node.Generate(testType1)
+			if err != nil {
+				t.Fatalf("Generate failed at iteration %d: %v", i, err)
+			}
+			if id.Seq() > SeqMax/2 {
+				t.Errorf("First sequence %d of a millisecond exceeds %d", id.Seq(), SeqMax/2)
+			}
+			if id.Seq() != 0 {
+				nonZero++
+			}
+		}
+		if nonZero == 0 {
+			t.Error("Expected random starting sequences, every millisecond started at 0")
+		}
+	})
+
+	t.Run("Unique", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithRandomSequenceStart(true))
+
+		const count = 20000
+		seen := make(map[ID]struct{}, count)
+		var last ID
+		for i := 0; i < count; i++ {
+			id, err := node.Generate(testType1)
+			if err != nil {
+				t.Fatalf("Generate failed at iteration %d: %v", i, err)
+			}
+			if id.Seq() < 0 || id.Seq() > SeqMax {
+				t.Fatalf("Sequence %d outside [0, %d]", id.Seq(), SeqMax)
+			}
+			if _, dup := seen[id]; dup {
+				t.Fatalf("Duplicate ID %d at iteration %d", id, i)
+			}
+			if id <= last {
+				t.Fatalf("ID %d not greater than previous %d", id, last)
+			}
+			seen[id] = struct{}{}
+			last = id
+		}
+
+		ids, err := node.GenerateBatch(testType1, 3000)
+		if err != nil {
+			t.Fatalf("GenerateBatch failed: %v", err)
+		}
+		for _, id := range ids {
+			if id <= last {
+				t.Fatalf("Batch ID %d not greater than previous %d", id, last)
+			}
+			last = id
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package arbiterid
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync/atomic"
+)
+
+// KeyShardBits is the number of high sequence bits GenerateForKey reserves for the key's
+// shard, giving 1<<KeyShardBits logical shards of 1<<(SeqBits-KeyShardBits) IDs per
+// millisecond each.
+const KeyShardBits = 4
+
+const keyShardShift = SeqBits - KeyShardBits
+
+// GenerateForKey creates a new ID whose sequence high bits hold a shard derived from a
+// hash of key, so every ID generated for the same key lands on the same logical shard
+// (see ID.KeyShard).
+//
+// Each shard counts its own sequences within a millisecond, and Generate and the other
+// methods skip the sequences claimed for keys, so keys on different shards do not use up
+// each other's sequences or those of Generate. IDs for a key increase and all of the
+// node's IDs are unique, but within a millisecond keyed IDs are not ordered against other
+// shards or against Generate: they bypass the strict monotonicity check and do not change
+// LastID, though State covers them. When the key's shard has no sequence left in the
+// current millisecond, generation moves on to the next one; as with GenerateAfter, the
+// internal time may run ahead of the wall clock by at most one second (or the
+// WithMaxFutureDrift limit, if set) before ErrClockTooFarAhead is returned.
+//
+// With WithBitLayout the shard occupies the top KeyShardBits of the node's sequence field,
+// which must be at least that wide; read it back with the node's Decoder. A custom
+// WithSequenceAllocator cannot skip claimed sequences, so with one GenerateForKey instead
+// draws and discards sequences outside the key's shard, and its IDs are ordered with the
+// node's other IDs.
+func (n *Node) GenerateForKey(idType IDType, key []byte) (ID, error) {
+	if err := n.validateType(idType); err != nil {
+		return 0, err
+	}
+	if n.layout.SeqBits < KeyShardBits {
+		return 0, fmt.Errorf("%w: GenerateForKey needs at least %d sequence bits, layout has %d",
+			ErrInvalidLayout, KeyShardBits, n.layout.SeqBits)
+	}
+	shard := keyShard(key)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	wall := n.currentMillis()
+	if err := n.checkRestoredClock(wall); err != nil {
+		return 0, err
+	}
+	a, ok := n.seqAllocator.(*incrementAllocator)
+	if !ok {
+		return n.generateForShardLocked(idType, shard, wall)
+	}
+
+	for {
+		now, seq, err := n.claimKeyedSeqLocked(a, shard, wall)
+		if err != nil {
+			return 0, err
+		}
+		if now > n.layout.timeMax() {
+			return 0, fmt.Errorf("%w: %dms exceeds maximum %dms", ErrTimestampOverflow, now, n.layout.timeMax())
+		}
+		// The zero ID at the epoch is never valid; its claim is kept and the next one used
+		if id := n.pack(idType, now, seq); id != 0 {
+			n.generated.Add(1)
+			if n.history != nil {
+				n.history.add(id)
+			}
+			return id, nil
+		}
+	}
+}
+
+// generateForShardLocked is GenerateForKey for a custom sequence allocator: it walks
+// forward through sequences and milliseconds until one falls in shard.
+func (n *Node) generateForShardLocked(idType IDType, shard, wall int64) (ID, error) {
+	shardShift := n.layout.SeqBits - KeyShardBits
+	now := max(wall, n.time)
+
+	var seq int64
+	for {
+		if err := n.checkAdvance(now, wall); err != nil {
+			return 0, err
+		}
+		var ok bool
+		if seq, ok = n.seqAllocator.Next(now); !ok {
+			n.sequenceRollovers++
+			now += n.granularity
+			continue
+		}
+		if seq>>shardShift == shard {
+			break
+		}
+	}
+	if err := n.setSeq(seq); err != nil {
+		return 0, err
+	}
+	return n.generateInternal(idType, now)
+}
+
+// keyedSeqs holds GenerateForKey's claims in one millisecond: for each shard, the next
+// sequence it may use. Every sequence of a shard below that is either claimed for a key or
+// was already behind the allocator when claiming moved past it, so the allocator, which
+// only moves forward, treats all of them as claimed.
+type keyedSeqs struct {
+	millis int64
+	next   [1 << KeyShardBits]atomic.Int64
+}
+
+// claimKeyedSeqLocked claims the next sequence of shard for GenerateForKey, at the latest
+// millisecond the node has used or later if the shard is full there.
+func (n *Node) claimKeyedSeqLocked(a *incrementAllocator, shard, wall int64) (millis, seq int64, err error) {
+	shift := n.layout.SeqBits - KeyShardBits
+	first, last := shard<<shift, (shard+1)<<shift-1
+
+	now := max(wall, n.time, int64(n.lastID)>>n.layout.timeShift()&n.layout.timeMax())
+	if state := a.state.Load(); state != noMillis {
+		now = max(now, state>>a.seqBits)
+	}
+	if k := a.keyed.Load(); k != nil {
+		now = max(now, k.millis)
+	}
+	for {
+		if err := n.checkAdvance(now, wall); err != nil {
+			return 0, 0, err
+		}
+		k := n.keyedSeqsLocked(a, now)
+		seq := max(first, k.next[shard].Load(), n.takenUpToLocked(a, now)+1)
+		if seq > last {
+			n.sequenceRollovers++
+			now += n.granularity
+			continue
+		}
+		k.next[shard].Store(seq + 1)
+		// GenerateAtomic may have taken seq before the claim was visible; if so, use the next
+		if state := a.state.Load(); state != noMillis && state>>a.seqBits == now && state&a.max >= seq {
+			continue
+		}
+		return now, seq, nil
+	}
+}
+
+// keyedSeqsLocked returns the allocator's keyed claims for millis, which is never earlier
+// than the last call's. Claims are only kept for one millisecond, so when moving on the
+// allocator and the node enter millis too: the rest of the node then generates there or
+// later, never in a millisecond whose claims are gone. They enter at sequence 0 rather than
+// a WithRandomSequenceStart offset, which would leave the shards below it no room.
+func (n *Node) keyedSeqsLocked(a *incrementAllocator, millis int64) *keyedSeqs {
+	k := a.keyed.Load()
+	if k != nil && k.millis == millis {
+		return k
+	}
+	k = &keyedSeqs{millis: millis}
+	a.keyed.Store(k)
+	for {
+		state := a.state.Load()
+		if state != noMillis && state>>a.seqBits >= millis {
+			break
+		}
+		if a.state.CompareAndSwap(state, millis<<a.seqBits) {
+			n.time, n.seq = millis, 0
+			break
+		}
+	}
+	return k
+}
+
+// takenUpToLocked returns the highest sequence in millis that the node's other methods
+// have used or passed, or -1 if none.
+func (n *Node) takenUpToLocked(a *incrementAllocator, millis int64) int64 {
+	taken := int64(-1)
+	if state := a.state.Load(); state != noMillis && state>>a.seqBits == millis {
+		taken = state & a.max
+	}
+	if n.time == millis {
+		taken = max(taken, n.seq)
+	}
+	if n.lastID != 0 && int64(n.lastID)>>n.layout.timeShift()&n.layout.timeMax() == millis {
+		taken = max(taken, int64(n.lastID)&n.layout.seqMax())
+	}
+	return taken
+}
+
+// claimedByKey reports whether the allocator must skip seq in millis because GenerateForKey
+// claimed it. k is the allocator's keyed claims as loaded before seq was taken: if they
+// have been replaced since, the claims seq has to be checked against are gone, so it is
+// skipped to be safe.
+func (a *incrementAllocator) claimedByKey(k *keyedSeqs, millis, seq int64) bool {
+	if a.keyed.Load() != k {
+		return true
+	}
+	if k == nil || k.millis != millis {
+		return false
+	}
+	return seq < k.next[seq>>(a.seqBits-KeyShardBits)].Load()
+}
+
+// unclaimedFrom returns the first sequence from seq on in millis not claimed by
+// GenerateForKey, which is past SeqMax if there is none.
+func (a *incrementAllocator) unclaimedFrom(millis, seq int64) int64 {
+	k := a.keyed.Load()
+	if k == nil || k.millis != millis {
+		return seq
+	}
+	for seq <= a.max {
+		next := k.next[seq>>(a.seqBits-KeyShardBits)].Load()
+		if seq >= next {
+			break
+		}
+		seq = next
+	}
+	return seq
+}
+
+// claimedAfter counts the sequences after seq in millis claimed by GenerateForKey.
+func (a *incrementAllocator) claimedAfter(millis, seq int64) int64 {
+	k := a.keyed.Load()
+	if k == nil || k.millis != millis {
+		return 0
+	}
+	shift := a.seqBits - KeyShardBits
+	var count int64
+	for shard := range k.next {
+		if next := k.next[shard].Load(); next > max(int64(shard)<<shift, seq+1) {
+			count += next - max(int64(shard)<<shift, seq+1)
+		}
+	}
+	return count
+}
+
+// keyedHigh returns the latest sequence claimed by GenerateForKey and its millisecond,
+// with ok=false if there is none.
+func (a *incrementAllocator) keyedHigh() (millis, seq int64, ok bool) {
+	k := a.keyed.Load()
+	if k == nil {
+		return 0, 0, false
+	}
+	seq = -1
+	for shard := range k.next {
+		seq = max(seq, k.next[shard].Load()-1)
+	}
+	return k.millis, seq, seq >= 0
+}
+
+// KeyShard returns the shard field of an ID created by GenerateForKey: the top
+// KeyShardBits bits of its sequence, assuming the default layout. For nodes with
+// WithBitLayout, use the node's Decoder.
+func (id ID) KeyShard() int64 {
+	return id.Seq() >> keyShardShift
+}
+
+// KeyShard returns the shard field of an ID created by GenerateForKey: the top
+// KeyShardBits bits of its sequence. It returns 0 for layouts too narrow for
+// GenerateForKey.
+func (d *Decoder) KeyShard(id ID) int64 {
+	if d.layout.SeqBits < KeyShardBits {
+		return 0
+	}
+	return d.Seq(id) >> (d.layout.SeqBits - KeyShardBits)
+}
+
+// keyShard hashes key into the range [0, 1<<KeyShardBits).
+func keyShard(key []byte) int64 {
+	h := fnv.New32a()
+	h.Write(key)
+	return int64(h.Sum32() % (1 << KeyShardBits))
+}
+
+// HashBucket maps the ID to one of n buckets, for consistent-hash sharding that should not
+// follow chronological order. The full 64-bit value is mixed with the MurmurHash3 64-bit
+// finalizer before reducing mod n, so consecutive IDs, which differ only in their low
+// sequence bits, spread uniformly across buckets. The result is stable across processes
+// and versions. It panics if n is not positive.
+func (id ID) HashBucket(n int) int {
+	if n <= 0 {
+		panic(fmt.Sprintf("arbiterid: HashBucket needs a positive bucket count, got %d", n))
+	}
+	return int(mix64(uint64(id)) % uint64(n))
+}
+
+// mix64 is the MurmurHash3 fmix64 finalizer, a bijection with full avalanche.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
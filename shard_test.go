@@ -0,0 +1,273 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateForKey(t *testing.T) {
+	start := time.Now()
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return start }))
+
+	key := []byte("customer-42")
+	want := keyShard(key)
+	seen := make(map[ID]bool)
+	var last ID
+	for i := 0; i < 200; i++ {
+		id, err := node.GenerateForKey(testType1, key)
+		if err != nil {
+			t.Fatalf("GenerateForKey failed at %d: %v", i, err)
+		}
+		if id.KeyShard() != want {
+			t.Fatalf("ID %d has shard %d, want %d", id, id.KeyShard(), want)
+		}
+		if id <= last {
+			t.Fatalf("IDs not increasing: %d after %d", id, last)
+		}
+		seen[id] = true
+		last = id
+	}
+
+	// 200 IDs for one shard cannot fit in a frozen millisecond
+	if rollovers := node.Stats().SequenceRollovers; rollovers == 0 {
+		t.Error("Expected GenerateForKey to count sequence rollovers")
+	}
+
+	// Plain generation does not reuse keyed sequences
+	for i := 0; i < 1000; i++ {
+		id := node.GenerateSimple(testType1)
+		if seen[id] {
+			t.Fatalf("Generate reissued keyed ID %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+// keysByShard returns one key for each shard.
+func keysByShard() [][]byte {
+	keys := make([][]byte, 1<<KeyShardBits)
+	for i, found := 0, 0; found < len(keys); i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		if shard := keyShard(key); keys[shard] == nil {
+			keys[shard] = key
+			found++
+		}
+	}
+	return keys
+}
+
+func TestGenerateForKey_ShardsShareMillisecond(t *testing.T) {
+	start := time.Now()
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return start }))
+	keys := keysByShard()
+
+	// Alternating shards fills the millisecond without moving the node's time
+	seen := make(map[ID]bool)
+	millis := int64(-1)
+	for round := 0; round < 63; round++ {
+		for shard, key := range keys {
+			id, err := node.GenerateForKey(testType1, key)
+			if err != nil {
+				t.Fatalf("GenerateForKey failed in round %d: %v", round, err)
+			}
+			if id.KeyShard() != int64(shard) || seen[id] {
+				t.Fatalf("ID %d has shard %d (want %d) or is a duplicate", id, id.KeyShard(), shard)
+			}
+			if millis == -1 {
+				millis = id.Time()
+			} else if id.Time() != millis {
+				t.Fatalf("Round %d moved to %dms, want all IDs at %dms", round, id.Time(), millis)
+			}
+			seen[id] = true
+		}
+	}
+	if rollovers := node.Stats().SequenceRollovers; rollovers != 0 {
+		t.Errorf("Expected no rollovers, got %d", rollovers)
+	}
+}
+
+func TestGenerateForKey_LeavesSequencesToGenerate(t *testing.T) {
+	start := time.Now()
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return start }))
+
+	keyed, err := node.GenerateForKey(testType1, keysByShard()[15])
+	if err != nil {
+		t.Fatalf("GenerateForKey failed: %v", err)
+	}
+	// Sequence 0 opens the millisecond and the keyed ID holds one more
+	if got, want := node.RemainingSequence(), SeqMax-1; got != want {
+		t.Errorf("RemainingSequence after one keyed ID = %d, want %d", got, want)
+	}
+	peeked, err := node.Peek(testType1)
+	if err != nil {
+		t.Fatalf("Peek failed: %v", err)
+	}
+	for i := int64(0); i < SeqMax-1; i++ {
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate %d failed: %v", i, err)
+		}
+		if i == 0 && id != peeked {
+			t.Errorf("Peek = %d, Generate = %d", peeked, id)
+		}
+		if id == keyed || id.Time() != keyed.Time() {
+			t.Fatalf("Generate %d returned %d, want a new ID at %dms", i, id, keyed.Time())
+		}
+	}
+	if got := node.RemainingSequence(); got != 0 {
+		t.Errorf("RemainingSequence = %d, want 0", got)
+	}
+}
+
+func TestGenerateForKey_Concurrent(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithStrictMonotonicityCheck(false))
+	keys := keysByShard()
+
+	const workers, perWorker = 8, 2000
+	results := make(chan ID, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				var id ID
+				var err error
+				switch {
+				case w%2 == 0:
+					id, err = node.GenerateForKey(testType1, keys[(w+i)%len(keys)])
+				case i%2 == 0:
+					id, err = node.GenerateAtomic(testType1)
+				default:
+					id, err = node.Generate(testType1)
+				}
+				if err != nil {
+					t.Errorf("Worker %d failed at %d: %v", w, i, err)
+					return
+				}
+				results <- id
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(results)
+
+	seen := make(map[ID]bool)
+	for id := range results {
+		if seen[id] {
+			t.Fatalf("Duplicate ID %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestGenerateForKey_State(t *testing.T) {
+	start := time.Now()
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return start }))
+	key := keysByShard()[15]
+	keyed, err := node.GenerateForKey(testType1, key)
+	if err != nil {
+		t.Fatalf("GenerateForKey failed: %v", err)
+	}
+
+	state := node.State()
+	if state.LastSeq != keyed.Seq() {
+		t.Errorf("State LastSeq = %d, want the keyed ID's %d", state.LastSeq, keyed.Seq())
+	}
+	restored, err := NewNodeFromState(state, WithQuietMode(true), WithNowFunc(func() time.Time { return start }))
+	if err != nil {
+		t.Fatalf("NewNodeFromState failed: %v", err)
+	}
+	if id, err := restored.GenerateForKey(testType1, key); err != nil || id <= keyed {
+		t.Errorf("Restored GenerateForKey = %d, %v; want an ID after %d", id, err, keyed)
+	}
+	if id, err := restored.Generate(testType1); err != nil || id <= keyed {
+		t.Errorf("Restored Generate = %d, %v; want an ID after %d", id, err, keyed)
+	}
+
+	// Like Generate, keyed generation waits for the clock to catch up with a restored state
+	behind := start.Add(-time.Minute)
+	restored, err = NewNodeFromState(state, WithQuietMode(true), WithNowFunc(func() time.Time { return behind }))
+	if err != nil {
+		t.Fatalf("NewNodeFromState failed: %v", err)
+	}
+	if _, err := restored.GenerateForKey(testType1, key); !errors.Is(err, ErrClockBehindState) {
+		t.Errorf("Expected ErrClockBehindState, got %v", err)
+	}
+}
+
+func TestDecoder_KeyShard(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithBitLayout(6, 2, 16))
+	key := []byte("customer-42")
+	id, err := node.GenerateForKey(testType1, key)
+	if err != nil {
+		t.Fatalf("GenerateForKey failed: %v", err)
+	}
+	if got := node.Decoder().KeyShard(id); got != keyShard(key) {
+		t.Errorf("Decoder.KeyShard = %d, want %d", got, keyShard(key))
+	}
+	if got := NewDecoderOnly(Epoch, Layout{TypeBits: 10, TimestampBits: 49, NodeBits: 1, SeqBits: 3}).KeyShard(id); got != 0 {
+		t.Errorf("KeyShard for a layout without shard bits = %d, want 0", got)
+	}
+}
+
+func TestGenerateForKey_Spread(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+
+	shards := make(map[int64]bool)
+	for i := 0; i < 100; i++ {
+		id, err := node.GenerateForKey(testType0, []byte(fmt.Sprintf("key-%d", i)))
+		if err != nil {
+			t.Fatalf("GenerateForKey failed: %v", err)
+		}
+		shards[id.KeyShard()] = true
+	}
+	if len(shards) < (1<<KeyShardBits)/2 {
+		t.Errorf("100 keys landed on only %d of %d shards", len(shards), 1<<KeyShardBits)
+	}
+
+	if _, err := node.GenerateForKey(IDType(TypeMax+1), []byte("k")); err == nil {
+		t.Error("Expected error for invalid type")
+	}
+}
+
+func TestID_HashBucket(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	// Consecutive IDs, which differ only in their low bits
+	ids, err := node.GenerateBatch(testType1, 100_000)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+
+	for _, buckets := range []int{7, 16, 100} {
+		counts := make([]int, buckets)
+		for _, id := range ids {
+			b := id.HashBucket(buckets)
+			if b < 0 || b >= buckets {
+				t.Fatalf("HashBucket(%d) = %d, out of range", buckets, b)
+			}
+			counts[b]++
+		}
+		want := float64(len(ids)) / float64(buckets)
+		for b, c := range counts {
+			if dev := (float64(c) - want) / want; dev > 0.15 || dev < -0.15 {
+				t.Errorf("%d buckets: bucket %d holds %d IDs, want about %.0f", buckets, b, c, want)
+			}
+		}
+	}
+
+	// The mapping is fixed, so stored bucket assignments stay valid
+	if got := idForEncodingTests.HashBucket(1000); got != 622 {
+		t.Errorf("HashBucket(1000) of %d = %d", idForEncodingTests, got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("HashBucket(0) should panic")
+		}
+	}()
+	ID(1).HashBucket(0)
+}
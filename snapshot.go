@@ -0,0 +1,29 @@
+package arbiterid
+
+// NodeSnapshot is a point-in-time copy of a Node's mutable generation state, for
+// debugging and for persisting state externally.
+type NodeSnapshot struct {
+	Time              int64 // Milliseconds since the node's epoch of the last generated ID
+	Seq               int64 // Sequence of the last generated ID
+	LastID            ID
+	ClockWarningCount int64 // Significant backwards clock movements observed
+}
+
+// Snapshot returns a copy of the node's mutable state, taken atomically under the node's
+// mutex so the fields are consistent with each other.
+func (n *Node) Snapshot() NodeSnapshot {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.snapshotLocked()
+}
+
+// snapshotLocked is Snapshot with the node's mutex already held.
+func (n *Node) snapshotLocked() NodeSnapshot {
+	n.syncLastIDLocked()
+	return NodeSnapshot{
+		Time:              n.time,
+		Seq:               n.seq,
+		LastID:            n.lastID,
+		ClockWarningCount: n.clockWarningCount,
+	}
+}
@@ -0,0 +1,43 @@
+package arbiterid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNode_Snapshot(t *testing.T) {
+	node := newTestNode(t, testNodeID0, WithQuietMode(true))
+	if got := node.Snapshot(); got != (NodeSnapshot{}) {
+		t.Errorf("Snapshot of fresh node = %+v, want zero value", got)
+	}
+
+	var last ID
+	for i := 0; i < 5; i++ {
+		last = node.GenerateSimple(testType1)
+	}
+
+	snap := node.Snapshot()
+	want := NodeSnapshot{
+		Time:              node.time,
+		Seq:               node.seq,
+		LastID:            node.lastID,
+		ClockWarningCount: node.clockWarningCount,
+	}
+	if snap != want {
+		t.Errorf("Snapshot() = %+v, want %+v", snap, want)
+	}
+	if snap.LastID != last || snap.Time != (int64(last)&TimestampMask)>>TimeShift || snap.Seq != last.Seq() {
+		t.Errorf("Snapshot %+v does not describe last ID %d", snap, last)
+	}
+
+	// Backwards clock movements are counted
+	base := time.Now()
+	offset := time.Duration(0)
+	clocked := newTestNode(t, testNodeID0, WithQuietMode(true), WithNowFunc(func() time.Time { return base.Add(offset) }))
+	clocked.GenerateSimple(testType1)
+	offset = -10 * time.Millisecond
+	clocked.GenerateSimple(testType1)
+	if got := clocked.Snapshot().ClockWarningCount; got != 1 {
+		t.Errorf("Expected ClockWarningCount 1, got %d", got)
+	}
+}
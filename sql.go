@@ -0,0 +1,44 @@
+package arbiterid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, storing the ID as an int64 (e.g. a bigint column).
+func (id ID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// Scan implements sql.Scanner. It accepts int64 values from integer columns and the
+// decimal form as string or []byte from text columns. A NULL leaves the ID as zero.
+// Negative values are rejected, since IDs are always positive.
+func (id *ID) Scan(src interface{}) error {
+	var val int64
+	switch v := src.(type) {
+	case nil:
+		*id = 0
+		return nil
+	case int64:
+		val = v
+	case string:
+		parsed, err := ParseString(v)
+		if err != nil {
+			return fmt.Errorf("arbiterid: cannot scan %q into ID: %w", v, err)
+		}
+		val = int64(parsed)
+	case []byte:
+		parsed, err := ParseString(string(v))
+		if err != nil {
+			return fmt.Errorf("arbiterid: cannot scan %q into ID: %w", v, err)
+		}
+		val = int64(parsed)
+	default:
+		return fmt.Errorf("arbiterid: cannot scan %T into ID", src)
+	}
+	if val < 0 {
+		return fmt.Errorf("arbiterid: scanned ID %d is negative, expected positive value", val)
+	}
+	*id = ID(val)
+	return nil
+}
@@ -0,0 +1,64 @@
+package arbiterid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = ID(0)
+	_ sql.Scanner   = (*ID)(nil)
+)
+
+func TestID_Value(t *testing.T) {
+	v, err := idForEncodingTests.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if got, ok := v.(int64); !ok || got != int64(idForEncodingTests) {
+		t.Errorf("Value() = %#v, want int64 %d", v, int64(idForEncodingTests))
+	}
+}
+
+func TestID_Scan(t *testing.T) {
+	want := idForEncodingTests
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"int64", int64(want)},
+		{"string", want.String()},
+		{"bytes", []byte(want.String())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id ID
+			if err := id.Scan(tt.src); err != nil {
+				t.Fatalf("Scan(%#v) failed: %v", tt.src, err)
+			}
+			if id != want {
+				t.Errorf("Scan(%#v) = %d, want %d", tt.src, id, want)
+			}
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		id := want
+		if err := id.Scan(nil); err != nil {
+			t.Errorf("Scan(nil) failed: %v", err)
+		}
+		if id != 0 {
+			t.Errorf("Scan(nil) left ID %d, want 0", id)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, src := range []interface{}{int64(-1), "-5", []byte("abc"), 3.14} {
+			var id ID
+			if err := id.Scan(src); err == nil {
+				t.Errorf("Scan(%#v) expected error, got ID %d", src, id)
+			}
+		}
+	})
+}
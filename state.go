@@ -0,0 +1,115 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Node state errors
+var (
+	ErrInvalidNodeState = errors.New("arbiterid: invalid node state")
+	ErrClockBehindState = errors.New("arbiterid: clock is behind the restored node state")
+)
+
+// NodeState is the generation state a node needs to resume after a restart without
+// reissuing or undercutting IDs it has already handed out. It marshals to JSON so it can
+// be written to disk, e.g. on shutdown or periodically.
+type NodeState struct {
+	Node     int64     `json:"node"`
+	LastTime time.Time `json:"last_time"` // Timestamp of the last generated ID
+	LastSeq  int64     `json:"last_seq"`  // Sequence of the last generated ID
+	LastID   ID        `json:"last_id"`
+}
+
+// State returns the node's current NodeState, for NewNodeFromState to resume from. It is
+// taken under the node's mutex, like Snapshot, so the fields are consistent.
+func (n *Node) State() NodeState {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncLastIDLocked()
+
+	millis, seq := n.allocatedLocked()
+	if a, ok := n.seqAllocator.(*incrementAllocator); ok {
+		if keyedMillis, keyedSeq, ok := a.keyedHigh(); ok && (keyedMillis > millis || keyedMillis == millis && keyedSeq > seq) {
+			millis, seq = keyedMillis, keyedSeq
+		}
+	}
+	last := n.lastID
+	if millis != n.time || seq != n.seq {
+		// GenerateAtomic or GenerateForKey has allocated past the last published ID; persist
+		// the allocation with the last ID's type so the restored node cannot reissue it
+		last = ID(int64(last)&n.layout.typeMask() | millis<<n.layout.timeShift() |
+			n.node<<n.layout.nodeShift() | seq)
+	}
+	return NodeState{
+		Node:     n.node,
+		LastTime: n.epoch.Add(time.Duration(millis) * time.Millisecond),
+		LastSeq:  seq,
+		LastID:   last,
+	}
+}
+
+// allocatedLocked returns the time and sequence of the latest allocation: the node's own
+// unless the default allocator is further ahead because GenerateAtomic's lock-free path
+// has taken sequences it has not published yet.
+func (n *Node) allocatedLocked() (millis, seq int64) {
+	millis, seq = n.time, n.seq
+	if a, ok := n.seqAllocator.(*incrementAllocator); ok {
+		if state := a.state.Load(); state != noMillis && state>>a.seqBits == millis {
+			seq = max(seq, state&a.max)
+		}
+	}
+	return millis, seq
+}
+
+// NewNodeFromState creates a node that resumes from state, which must have been saved from
+// a node with the same epoch and layout; pass the same options as the original node. The
+// node continues after state.LastID: if the clock has moved backwards across the restart,
+// for example after an NTP correction or VM migration, generation carries on from
+// state.LastTime while the clock is behind it by no more than the future-drift limit (one
+// second, or WithMaxFutureDrift). Further behind, Generate and the batch methods fail with
+// ErrClockBehindState until real time catches up. A state that does not fit the node's
+// layout yields ErrInvalidNodeState.
+func NewNodeFromState(state NodeState, options ...NodeOption) (*Node, error) {
+	n, err := NewNode(int(state.Node), append(options, WithMinimumID(state.LastID))...)
+	if err != nil {
+		return nil, err
+	}
+
+	millis := state.LastTime.Sub(n.epoch).Milliseconds()
+	if state.LastID == 0 && millis <= 0 {
+		return n, nil // Saved before the node generated anything
+	}
+	if millis < 0 || millis > n.layout.timeMax() {
+		return nil, fmt.Errorf("%w: last time %s is outside the node's timestamp range",
+			ErrInvalidNodeState, state.LastTime.UTC().Format(time.RFC3339Nano))
+	}
+	if err := n.setSeq(state.LastSeq); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNodeState, err)
+	}
+	n.time = millis
+	n.restoredTime = millis
+	if a, ok := n.seqAllocator.(*incrementAllocator); ok {
+		a.state.Store(millis<<a.seqBits | state.LastSeq)
+	}
+	return n, nil
+}
+
+// checkRestoredClock returns ErrClockBehindState while the wall clock is further behind a
+// restored state's last time than the node may run ahead of it. Once the clock has caught
+// up the check is switched off.
+func (n *Node) checkRestoredClock(wall int64) error {
+	if n.restoredTime == 0 {
+		return nil
+	}
+	if wall >= n.restoredTime {
+		n.restoredTime = 0
+		return nil
+	}
+	if behind := time.Duration(n.restoredTime-wall) * time.Millisecond; behind > n.maxFutureAdvance() {
+		return fmt.Errorf("%w: clock at %dms is %s behind restored time %dms (max %s)",
+			ErrClockBehindState, wall, behind, n.restoredTime, n.maxFutureAdvance())
+	}
+	return nil
+}
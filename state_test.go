@@ -0,0 +1,152 @@
+package arbiterid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNodeState(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	saved := func(t *testing.T) NodeState {
+		t.Helper()
+		clock := NewManualClock(start)
+		node := newTestNode(t, testNodeID1, WithQuietMode(true), WithManualClock(clock))
+		for i := 0; i < 3; i++ {
+			if _, err := node.Generate(testType1); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+		}
+		return node.State()
+	}
+
+	t.Run("State and JSON", func(t *testing.T) {
+		state := saved(t)
+		if state.Node != testNodeID1 || !state.LastTime.Equal(start) || state.LastSeq != 2 {
+			t.Errorf("Unexpected state %+v", state)
+		}
+		if state.LastID.Time() != start.UnixMilli() || state.LastID.Seq() != 2 {
+			t.Errorf("LastID %d does not match state %+v", state.LastID, state)
+		}
+
+		data, err := json.Marshal(state)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var decoded NodeState
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal %s failed: %v", data, err)
+		}
+		if decoded.Node != state.Node || !decoded.LastTime.Equal(state.LastTime) ||
+			decoded.LastSeq != state.LastSeq || decoded.LastID != state.LastID {
+			t.Errorf("JSON round trip gave %+v, want %+v", decoded, state)
+		}
+	})
+
+	t.Run("Clock behind state", func(t *testing.T) {
+		state := saved(t)
+		clock := NewManualClock(start.Add(-time.Minute))
+		node, err := NewNodeFromState(state, WithQuietMode(true), WithManualClock(clock))
+		if err != nil {
+			t.Fatalf("NewNodeFromState failed: %v", err)
+		}
+
+		if _, err := node.Generate(testType1); !errors.Is(err, ErrClockBehindState) {
+			t.Fatalf("Expected ErrClockBehindState, got %v", err)
+		}
+		if ClassifyError(ErrClockBehindState) != KindClockStuck {
+			t.Errorf("Expected ErrClockBehindState to classify as KindClockStuck")
+		}
+		if _, err := node.GenerateBatch(testType1, 2); !errors.Is(err, ErrClockBehindState) {
+			t.Errorf("Expected GenerateBatch to fail with ErrClockBehindState, got %v", err)
+		}
+
+		clock.Set(start.Add(time.Millisecond))
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate after the clock caught up failed: %v", err)
+		}
+		if id <= state.LastID {
+			t.Errorf("ID %d does not follow restored last ID %d", id, state.LastID)
+		}
+
+		// Once caught up, later backwards moves are handled as usual
+		clock.Set(start.Add(-time.Minute))
+		if next, err := node.Generate(testType1); err != nil || next <= id {
+			t.Errorf("Generate after a later backwards move = %d, %v; want > %d", next, err, id)
+		}
+	})
+
+	t.Run("Clock within tolerance", func(t *testing.T) {
+		state := saved(t)
+		clock := NewManualClock(start.Add(-500 * time.Millisecond))
+		node, err := NewNodeFromState(state, WithQuietMode(true), WithManualClock(clock))
+		if err != nil {
+			t.Fatalf("NewNodeFromState failed: %v", err)
+		}
+
+		id, err := node.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if id.Time() != start.UnixMilli() || id.Seq() != 3 {
+			t.Errorf("Expected the restored millisecond with seq 3, got time %d seq %d", id.Time(), id.Seq())
+		}
+	})
+
+	t.Run("Invalid state", func(t *testing.T) {
+		state := saved(t)
+		state.LastSeq = SeqMax + 1
+		if _, err := NewNodeFromState(state, WithQuietMode(true)); !errors.Is(err, ErrInvalidNodeState) {
+			t.Errorf("Expected ErrInvalidNodeState for an out-of-range sequence, got %v", err)
+		}
+
+		state = saved(t)
+		state.LastTime = start.AddDate(-10, 0, 0)
+		if _, err := NewNodeFromState(state, WithQuietMode(true)); !errors.Is(err, ErrInvalidNodeState) {
+			t.Errorf("Expected ErrInvalidNodeState for a time before the epoch, got %v", err)
+		}
+
+		if _, err := NewNodeFromState(NodeState{Node: NodeMax + 1}); !errors.Is(err, ErrInvalidNodeID) {
+			t.Errorf("Expected ErrInvalidNodeID, got %v", err)
+		}
+	})
+}
+
+func TestNodeState_GenerateAtomic(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	opts := []NodeOption{WithQuietMode(true), WithTypeAgnosticMonotonicity(true), WithManualClock(clock)}
+	node := newTestNode(t, testNodeID1, opts...)
+
+	// The first ID takes the locked path; the rest take sequences lock-free
+	issued := make(map[ID]bool)
+	for i := 0; i < 10; i++ {
+		id, err := node.GenerateAtomic(testType1)
+		if err != nil {
+			t.Fatalf("GenerateAtomic failed: %v", err)
+		}
+		issued[id] = true
+	}
+	state := node.State()
+	if state.LastSeq != 9 || state.LastID.Seq() != 9 {
+		t.Fatalf("State() = %+v, want the lock-free IDs up to seq 9", state)
+	}
+
+	clock.Set(start.Add(-100 * time.Millisecond))
+	restored, err := NewNodeFromState(state, opts...)
+	if err != nil {
+		t.Fatalf("NewNodeFromState failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		id, err := restored.Generate(testType1)
+		if err != nil {
+			t.Fatalf("Generate after restore failed: %v", err)
+		}
+		if issued[id] || id <= state.LastID {
+			t.Fatalf("Restored node reissued or undercut ID %d (seq %d), last saved %d", id, id.Seq(), state.LastID)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package arbiterid
+
+// Stats holds a node's generation counters for monitoring, such as exporting to a
+// metrics system. Counters only increase over the node's lifetime.
+type Stats struct {
+	TotalGenerated         int64 // IDs generated, the same as GeneratedCount
+	ClockBackwardsEvents   int64 // Significant backwards clock movements observed
+	SequenceRollovers      int64 // Times a millisecond's sequences ran out and generation moved to a later one
+	MonotonicityViolations int64 // Generations that failed with ErrMonotonicityViolation
+	LastTimestampMillis    int64 // Unix milliseconds of the node's latest generation time; zero before the first ID
+}
+
+// Stats returns a copy of the node's generation counters, taken under the node's mutex
+// so they are consistent with each other. The returned value is safe to read and keep
+// while the node continues generating.
+func (n *Node) Stats() Stats {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncLastIDLocked()
+
+	s := Stats{
+		TotalGenerated:         n.generated.Load(),
+		ClockBackwardsEvents:   n.clockWarningCount,
+		SequenceRollovers:      n.sequenceRollovers,
+		MonotonicityViolations: n.monotonicityViolations,
+	}
+	if n.lastID != 0 || n.time != 0 {
+		s.LastTimestampMillis = n.epoch.UnixMilli() + n.time
+	}
+	return s
+}
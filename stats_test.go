@@ -0,0 +1,55 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNode_Stats(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Counters", func(t *testing.T) {
+		// A full millisecond plus one ID, a stalled read while waiting for rollover,
+		// the next millisecond, then a backwards jump
+		times := make([]time.Time, 0, SeqMax+5)
+		for i := int64(0); i <= SeqMax+2; i++ {
+			times = append(times, start)
+		}
+		times = append(times, start.Add(time.Millisecond), start.Add(-time.Second))
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithClock(&scriptedClock{times: times}))
+
+		if got := node.Stats(); got != (Stats{}) {
+			t.Errorf("Expected zero stats for a fresh node, got %+v", got)
+		}
+
+		for i := int64(0); i <= SeqMax+2; i++ {
+			node.GenerateSimple(testType1)
+		}
+		want := Stats{
+			TotalGenerated:       SeqMax + 3,
+			ClockBackwardsEvents: 1,
+			SequenceRollovers:    1,
+			LastTimestampMillis:  start.Add(time.Millisecond).UnixMilli(),
+		}
+		if got := node.Stats(); got != want {
+			t.Errorf("Stats() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Monotonicity violations", func(t *testing.T) {
+		floor, err := newTestNode(t, testNodeID1, WithQuietMode(true)).GenerateWithTimestamp(testType1, start.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("GenerateWithTimestamp failed: %v", err)
+		}
+		node := newTestNode(t, testNodeID0, WithQuietMode(true), WithManualClock(NewManualClock(start)), WithMinimumID(floor))
+		for i := 0; i < 2; i++ {
+			if _, err := node.Generate(testType1); !errors.Is(err, ErrMonotonicityViolation) {
+				t.Fatalf("Expected ErrMonotonicityViolation, got %v", err)
+			}
+		}
+		if got := node.Stats(); got.MonotonicityViolations != 2 || got.TotalGenerated != 0 {
+			t.Errorf("Expected 2 violations and no IDs, got %+v", got)
+		}
+	})
+}
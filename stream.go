@@ -0,0 +1,35 @@
+package arbiterid
+
+import "context"
+
+// Stream generates IDs of the given type in a background goroutine and delivers them on a
+// channel buffered to hold bufSize IDs, for pull-style consumers. Buffered IDs are
+// generated ahead of consumption, so their timestamps reflect when they were generated,
+// not when they were received; they are unique and increasing like any other IDs from
+// the node.
+//
+// The goroutine stops and closes both channels when ctx is cancelled; an ID generated but
+// not yet delivered at that point is discarded. If generation fails, the error is sent on
+// the error channel, which has room for it, and both channels are closed.
+func (n *Node) Stream(ctx context.Context, idType IDType, bufSize int) (<-chan ID, <-chan error) {
+	ids := make(chan ID, max(bufSize, 0))
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(ids)
+		for ctx.Err() == nil {
+			id, err := n.Generate(idType)
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case ids <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ids, errs
+}
@@ -0,0 +1,75 @@
+package arbiterid
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNode_Stream(t *testing.T) {
+	t.Run("DeliversUniqueIncreasingIDs", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ids, errs := node.Stream(ctx, testType1, 64)
+		// Let the buffer fill before consuming, then interleave direct generation
+		time.Sleep(10 * time.Millisecond)
+		got := make([]ID, 0, 3000)
+		for len(got) < 3000 {
+			got = append(got, <-ids)
+		}
+		if _, err := node.Generate(testType1); err != nil {
+			t.Fatalf("Generate alongside Stream failed: %v", err)
+		}
+		if _, err := VerifyMonotonic(got); err != nil {
+			t.Error(err)
+		}
+		select {
+		case err := <-errs:
+			t.Errorf("Unexpected stream error: %v", err)
+		default:
+		}
+	})
+
+	t.Run("StopsOnCancel", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		ctx, cancel := context.WithCancel(context.Background())
+		ids, errs := node.Stream(ctx, testType1, 8)
+		<-ids
+		cancel()
+
+		// Both channels close once the goroutine has exited; drain what was buffered
+		deadline := time.After(time.Second)
+		for ids != nil || errs != nil {
+			select {
+			case _, ok := <-ids:
+				if !ok {
+					ids = nil
+				}
+			case err, ok := <-errs:
+				if ok {
+					t.Errorf("Unexpected error after cancel: %v", err)
+				}
+				errs = nil
+			case <-deadline:
+				t.Fatal("Stream did not stop after cancel")
+			}
+		}
+	})
+
+	t.Run("ErrorClosesChannels", func(t *testing.T) {
+		node := newTestNode(t, testNodeID0, WithQuietMode(true))
+		ids, errs := node.Stream(context.Background(), IDType(TypeMax+1), 4)
+		if err := <-errs; !errors.Is(err, ErrInvalIDType) {
+			t.Errorf("Expected ErrInvalIDType, got %v", err)
+		}
+		if _, ok := <-ids; ok {
+			t.Error("ID channel should be closed after an error")
+		}
+		if _, ok := <-errs; ok {
+			t.Error("Error channel should be closed after an error")
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package arbiterid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateVersioned(t *testing.T) {
+	const versionBits = 3 // 8 versions of 128 base types
+	node := newTestNode(t, testNodeID0, WithQuietMode(true), WithTypeVersioning(versionBits))
+
+	tests := []struct {
+		version, base uint16
+	}{
+		{0, 0}, {0, 127}, {2, 5}, {7, 127},
+	}
+	for _, tt := range tests {
+		id, err := node.GenerateVersioned(tt.version, tt.base)
+		if err != nil {
+			t.Fatalf("GenerateVersioned(%d, %d) failed: %v", tt.version, tt.base, err)
+		}
+		if got := id.TypeVersion(versionBits); got != int64(tt.version) {
+			t.Errorf("TypeVersion = %d, want %d", got, tt.version)
+		}
+		if got := id.TypeBase(versionBits); got != int64(tt.base) {
+			t.Errorf("TypeBase = %d, want %d", got, tt.base)
+		}
+		if want := int64(tt.version)<<(TypeBits-versionBits) | int64(tt.base); id.Type() != want {
+			t.Errorf("Type = %d, want %d", id.Type(), want)
+		}
+	}
+
+	if _, err := node.GenerateVersioned(8, 0); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType for oversized version, got %v", err)
+	}
+	if _, err := node.GenerateVersioned(0, 128); !errors.Is(err, ErrInvalIDType) {
+		t.Errorf("Expected ErrInvalIDType for oversized base type, got %v", err)
+	}
+}
+
+func TestWithTypeVersioning_Validation(t *testing.T) {
+	if _, err := NewNode(0, WithQuietMode(true), WithTypeVersioning(TypeBits)); !errors.Is(err, ErrInvalidTypeVersioning) {
+		t.Errorf("Expected ErrInvalidTypeVersioning, got %v", err)
+	}
+	plain := newTestNode(t, testNodeID0, WithQuietMode(true))
+	if _, err := plain.GenerateVersioned(0, 1); !errors.Is(err, ErrTypeVersioningOff) {
+		t.Errorf("Expected ErrTypeVersioningOff, got %v", err)
+	}
+
+	id := plain.GenerateSimple(testTypeMax)
+	if id.TypeVersion(0) != 0 || id.TypeBase(0) != id.Type() {
+		t.Errorf("Zero version bits should leave the whole type as the base")
+	}
+}
@@ -0,0 +1,47 @@
+package arbiterid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidUUID is returned by FromUUID for a UUID that ToUUID did not produce.
+var ErrInvalidUUID = errors.New("arbiterid: UUID does not embed an ID")
+
+// UUID version and variant markers set by ToUUID
+const (
+	uuidVersion8   = 0x80 // Version nibble in byte 6
+	uuidVariantRFC = 0x80 // Top two bits of byte 8: 10
+)
+
+// ToUUID embeds the ID in a valid version 8 (custom) UUID, for systems that only accept
+// UUID keys. This is a lossless embedding, not a semantic UUID: the ID is zero-padded on
+// the left, with its top bit in byte 7 and its other 62 bits after the variant bits in
+// bytes 8-15. Bytes 0-5 are zero and byte 6 holds the version, so the UUIDs compare
+// bytewise in the same order as the IDs. FromUUID reverses it.
+func (id ID) ToUUID() [16]byte {
+	var u [16]byte
+	v := uint64(id)
+	u[6] = uuidVersion8
+	u[7] = byte(v >> 62 & 1)
+	binary.BigEndian.PutUint64(u[8:], v&(1<<62-1))
+	u[8] |= uuidVariantRFC
+	return u
+}
+
+// FromUUID extracts the ID embedded by ToUUID. It returns ErrInvalidUUID unless the
+// padding, version and variant bits are exactly those ToUUID sets, so arbitrary UUIDs are
+// rejected rather than truncated.
+func FromUUID(u [16]byte) (ID, error) {
+	for _, b := range u[:6] {
+		if b != 0 {
+			return 0, fmt.Errorf("%w: non-zero padding in %x", ErrInvalidUUID, u)
+		}
+	}
+	if u[6] != uuidVersion8 || u[7]&^1 != 0 || u[8]&0xC0 != uuidVariantRFC {
+		return 0, fmt.Errorf("%w: unexpected version or variant bits in %x", ErrInvalidUUID, u)
+	}
+	low := binary.BigEndian.Uint64(u[8:]) &^ (0xC0 << 56)
+	return ID(uint64(u[7])<<62 | low), nil
+}
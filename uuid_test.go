@@ -0,0 +1,63 @@
+package arbiterid
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestID_ToUUID_FromUUID(t *testing.T) {
+	ids := []ID{0, 1, idForEncodingTests, 1 << 61, 1 << 62, ID(math.MaxInt64)}
+	for i, id := range ids {
+		u := id.ToUUID()
+		if version := u[6] >> 4; version != 8 {
+			t.Errorf("ToUUID(%d): version %d, want 8", id, version)
+		}
+		if variant := u[8] >> 6; variant != 0b10 {
+			t.Errorf("ToUUID(%d): variant bits %02b, want 10", id, variant)
+		}
+		parsed, err := FromUUID(u)
+		if err != nil {
+			t.Fatalf("FromUUID(%x) failed: %v", u, err)
+		}
+		if parsed != id {
+			t.Errorf("FromUUID(ToUUID(%d)) = %d", id, parsed)
+		}
+		if i > 0 {
+			prev := ids[i-1].ToUUID()
+			if bytes.Compare(prev[:], u[:]) >= 0 {
+				t.Errorf("UUID of %d does not sort after UUID of %d", id, ids[i-1])
+			}
+		}
+	}
+
+	if got := idForEncodingTests.ToUUID(); got != [16]byte{6: 0x80, 8: 0x91, 0x22, 0x10, 0xf4, 0x7d, 0xe9, 0x81, 0x15} {
+		t.Errorf("ToUUID(%d) = %x", idForEncodingTests, got)
+	}
+}
+
+func TestFromUUID_Invalid(t *testing.T) {
+	valid := idForEncodingTests.ToUUID()
+	tests := []struct {
+		name   string
+		mutate func(u *[16]byte)
+	}{
+		{"Padding", func(u *[16]byte) { u[0] = 1 }},
+		{"Version 4", func(u *[16]byte) { u[6] = 0x40 }},
+		{"Byte 7 padding", func(u *[16]byte) { u[7] |= 0x02 }},
+		{"Variant", func(u *[16]byte) { u[8] &^= 0x80 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := valid
+			tt.mutate(&u)
+			if _, err := FromUUID(u); !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("Expected ErrInvalidUUID, got %v", err)
+			}
+		})
+	}
+	if _, err := FromUUID([16]byte{}); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("Expected ErrInvalidUUID for the nil UUID, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package arbiterid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// validFutureTolerance is how far past the current time an ID's timestamp may be
+// and still be considered plausible, allowing for clock skew between nodes.
+const validFutureTolerance = time.Hour
+
+// ErrInvalidID is returned when a decoded value is not a plausible ID.
+var ErrInvalidID = errors.New("arbiterid: invalid ID")
+
+// IsValid reports whether the ID is plausible: positive, and not timestamped
+// further in the future than clock skew could explain.
+// The zero ID is never valid.
+func (id ID) IsValid() bool {
+	return id.Validate() == nil
+}
+
+// Validate checks the structural constraints of an ID received from an untrusted source
+// and returns an ErrInvalidID error describing the first one it breaks:
+//
+//   - the sign bit must be clear and the ID non-zero
+//   - type, node, sequence and timestamp must fit their fields (TypeMax, NodeMax, SeqMax,
+//     TimestampMax after the package Epoch)
+//   - the timestamp must not be further in the future than clock skew could explain
+//
+// Once the sign bit is clear, the 63-bit layout already confines every component to its
+// field, so in practice a forged ID fails on the sign or timestamp checks.
+func (id ID) Validate() error {
+	if id < 0 {
+		return fmt.Errorf("%w: %d has the sign bit set", ErrInvalidID, int64(id))
+	}
+	if id == 0 {
+		return fmt.Errorf("%w: zero ID", ErrInvalidID)
+	}
+
+	idType, tsUnix, node, seq := id.Components()
+	switch {
+	case uint16(idType) > TypeMax:
+		return fmt.Errorf("%w: type %d exceeds %d", ErrInvalidID, idType, TypeMax)
+	case node > NodeMax:
+		return fmt.Errorf("%w: node %d exceeds %d", ErrInvalidID, node, NodeMax)
+	case seq > SeqMax:
+		return fmt.Errorf("%w: seq %d exceeds %d", ErrInvalidID, seq, SeqMax)
+	case tsUnix < Epoch || tsUnix-Epoch > TimestampMax:
+		return fmt.Errorf("%w: timestamp %dms is outside the epoch range", ErrInvalidID, tsUnix)
+	}
+
+	if limit := time.Now().Add(validFutureTolerance); id.TimeTime().After(limit) {
+		return fmt.Errorf("%w: timestamp %s is more than %s in the future",
+			ErrInvalidID, id.TimeISO(), validFutureTolerance)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package arbiterid
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestID_Validate(t *testing.T) {
+	node := newTestNode(t, testNodeID1, WithQuietMode(true))
+	generated := node.GenerateSimple(testTypeMax)
+	if err := generated.Validate(); err != nil {
+		t.Errorf("Validate() on a generated ID failed: %v", err)
+	}
+	if !generated.IsValid() {
+		t.Error("IsValid() = false for a generated ID")
+	}
+
+	future, err := newTestNode(t, testNodeID0, WithQuietMode(true)).
+		GenerateWithTimestamp(testType1, time.Now().AddDate(30, 0, 0))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   ID
+	}{
+		{"Zero", 0},
+		{"Negative", ID(-1)},
+		{"SignBitOnly", ID(math.MinInt64)},
+		{"FarFuture", future},
+		{"MaxInt64", ID(math.MaxInt64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.id.Validate(); !errors.Is(err, ErrInvalidID) {
+				t.Errorf("Validate(%d) = %v, want ErrInvalidID", tt.id, err)
+			}
+			if tt.id.IsValid() {
+				t.Errorf("IsValid(%d) = true, want false", tt.id)
+			}
+		})
+	}
+
+	// A future timestamp within the clock skew tolerance is still accepted
+	nearFuture, err := newTestNode(t, testNodeID0, WithQuietMode(true)).
+		GenerateWithTimestamp(testType1, time.Now().Add(validFutureTolerance/2))
+	if err != nil {
+		t.Fatalf("GenerateWithTimestamp failed: %v", err)
+	}
+	if err := nearFuture.Validate(); err != nil {
+		t.Errorf("Validate() rejected an ID within the skew tolerance: %v", err)
+	}
+}